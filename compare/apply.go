@@ -0,0 +1,273 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// Side identifies one of the two values compared to produce a Delta
+type Side int
+
+const (
+	// SideA refers to the first value under comparison and the A value of
+	// each Difference
+	SideA Side = iota
+	// SideB refers to the second value under comparison and the B value of
+	// each Difference
+	SideB
+)
+
+// UnreachablePathError is returned by Delta.ApplyTo when a Difference's value
+// cannot be set on the target at the Difference's Path
+type UnreachablePathError struct {
+	// Path is the Path of the Difference that could not be applied
+	Path Path
+	// Reason describes why the Path could not be reached
+	Reason string
+}
+
+// Error implements the error interface
+func (e *UnreachablePathError) Error() string {
+	return fmt.Sprintf("cannot apply difference at %q: %s", e.Path, e.Reason)
+}
+
+// ApplyTo sets the A or B value, depending on the supplied side, of each
+// Difference in the Delta on the supplied target at the Difference's Path.
+// The target must be a non-nil pointer (typically to a struct) or a map with
+// string keys, e.g. a map[string]interface{}.
+//
+// Struct fields are looked up by their Go field name, map entries by key and
+// list elements by their bracketed index or, for lists compared using
+// KeyedSlice, by their key. Nil pointers, maps and interfaces along the Path
+// are initialized as needed, with nil interfaces initialized to a
+// map[string]interface{}. List elements selected by index are never created,
+// so an index out of range is unreachable, while elements selected by key are
+// appended to or removed from slices as needed. A nil value sets the field to
+// its zero value and removes map entries.
+//
+// Values are assigned as is, not copied, so the target may share memory with
+// the values that were compared. If a Difference cannot be applied, an
+// *UnreachablePathError is returned and the Differences after it are not
+// applied. If the Delta contains an Embedded Difference (see
+// EmbeddedDocuments), or a sensitive Difference decoded from JSON whose value
+// for the supplied side was redacted (see SensitivePaths), an
+// *UnreachablePathError is returned before any Difference is applied.
+func (d *Delta) ApplyTo(target any, side Side) error {
+	if diff := d.hasEmbedded(); diff != nil {
+		return &UnreachablePathError{
+			Path:   diff.Path,
+			Reason: "difference is within a document held by a string field",
+		}
+	}
+	if diff := d.hasRedacted(side); diff != nil {
+		return &UnreachablePathError{
+			Path:   diff.Path,
+			Reason: "sensitive value was redacted when the delta was encoded",
+		}
+	}
+	v := reflect.ValueOf(target)
+	switch {
+	case v.Kind() == reflect.Ptr && !v.IsNil():
+		v = v.Elem()
+	case v.Kind() == reflect.Map && !v.IsNil():
+	default:
+		return fmt.Errorf(
+			"cannot apply delta to %T: target must be a non-nil pointer or map",
+			target,
+		)
+	}
+	for _, diff := range d.Differences {
+		value := diff.A
+		if side == SideB {
+			value = diff.B
+		}
+		parts := diff.Path.parts
+		if diff.Path.isRoot() {
+			parts = nil
+		}
+		if reason := setPath(v, parts, value); reason != "" {
+			return &UnreachablePathError{Path: diff.Path, Reason: reason}
+		}
+	}
+	return nil
+}
+
+// setPath sets the supplied value at the supplied Path parts beneath v. It
+// returns a description of the problem if the Path cannot be reached.
+func setPath(v reflect.Value, parts []string, value any) string {
+	if len(parts) == 0 {
+		return setValue(v, value)
+	}
+	part := parts[0]
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			if !v.CanSet() {
+				return fmt.Sprintf("nil %s cannot be initialized", v.Type())
+			}
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return setPath(v.Elem(), parts, value)
+	case reflect.Interface:
+		if !v.CanSet() {
+			return fmt.Sprintf("%s cannot be set", v.Type())
+		}
+		var inner reflect.Value
+		if v.IsNil() {
+			inner = reflect.ValueOf(map[string]interface{}{})
+		} else {
+			inner = v.Elem()
+		}
+		// The value held by an interface is not addressable, so we modify a
+		// copy of it and store the copy back in the interface
+		cp := reflect.New(inner.Type()).Elem()
+		cp.Set(inner)
+		if reason := setPath(cp, parts, value); reason != "" {
+			return reason
+		}
+		v.Set(cp)
+		return ""
+	case reflect.Struct:
+		field, ok := v.Type().FieldByName(part)
+		if !ok || !field.IsExported() {
+			return fmt.Sprintf("%s has no exported field %q", v.Type(), part)
+		}
+		fv := v
+		for x, index := range field.Index {
+			if x > 0 && fv.Kind() == reflect.Ptr {
+				// Promoted field of an embedded struct pointer
+				if fv.IsNil() {
+					if !fv.CanSet() {
+						return fmt.Sprintf("nil %s cannot be initialized", fv.Type())
+					}
+					fv.Set(reflect.New(fv.Type().Elem()))
+				}
+				fv = fv.Elem()
+			}
+			fv = fv.Field(index)
+		}
+		return setPath(fv, parts[1:], value)
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return fmt.Sprintf("unsupported map key type %s", v.Type().Key())
+		}
+		if v.IsNil() {
+			if !v.CanSet() {
+				return fmt.Sprintf("nil %s cannot be initialized", v.Type())
+			}
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		key := reflect.ValueOf(part).Convert(v.Type().Key())
+		if len(parts) == 1 && value == nil {
+			v.SetMapIndex(key, reflect.Value{})
+			return ""
+		}
+		// Map entries are not addressable, so we modify a copy of the entry
+		// and store the copy back in the map
+		entry := reflect.New(v.Type().Elem()).Elem()
+		if existing := v.MapIndex(key); existing.IsValid() {
+			entry.Set(existing)
+		}
+		if reason := setPath(entry, parts[1:], value); reason != "" {
+			return reason
+		}
+		v.SetMapIndex(key, entry)
+		return ""
+	case reflect.Slice, reflect.Array:
+		if keyField, key, ok := parseKeyedPart(part); ok {
+			return setKeyedElement(v, keyField, key, parts[1:], value)
+		}
+		if !isElementPart(part) {
+			return fmt.Sprintf("%s has no field %q", v.Type(), part)
+		}
+		index, err := strconv.Atoi(part[1 : len(part)-1])
+		if err != nil || index < 0 || index >= v.Len() {
+			return fmt.Sprintf(
+				"index %s out of range for %s of length %d",
+				part, v.Type(), v.Len(),
+			)
+		}
+		return setPath(v.Index(index), parts[1:], value)
+	}
+	return fmt.Sprintf("%s has no field %q", v.Type(), part)
+}
+
+// setKeyedElement sets the supplied value at the supplied Path parts beneath
+// the element of the supplied list whose key field has the supplied key. If
+// there are no remaining parts, the element is appended to a slice if it is
+// missing, and removed from a slice if the value is nil.
+func setKeyedElement(
+	v reflect.Value,
+	keyField, key string,
+	parts []string,
+	value any,
+) string {
+	index := -1
+	for x := 0; x < v.Len(); x++ {
+		if elemKey, ok := elementKey(v.Index(x), keyField); ok && elemKey == key {
+			index = x
+			break
+		}
+	}
+	if len(parts) > 0 {
+		if index < 0 {
+			return fmt.Sprintf("no element with %s=%s in %s", keyField, key, v.Type())
+		}
+		return setPath(v.Index(index), parts, value)
+	}
+	if index >= 0 && value != nil {
+		return setValue(v.Index(index), value)
+	}
+	if index < 0 && value == nil {
+		return ""
+	}
+	if v.Kind() != reflect.Slice || !v.CanSet() {
+		return fmt.Sprintf("%s cannot be resized", v.Type())
+	}
+	if value == nil {
+		elems := reflect.MakeSlice(v.Type(), 0, v.Len()-1)
+		elems = reflect.AppendSlice(elems, v.Slice(0, index))
+		v.Set(reflect.AppendSlice(elems, v.Slice(index+1, v.Len())))
+		return ""
+	}
+	elem := reflect.New(v.Type().Elem()).Elem()
+	if reason := setValue(elem, value); reason != "" {
+		return reason
+	}
+	v.Set(reflect.Append(v, elem))
+	return ""
+}
+
+// setValue sets v to the supplied value, or to its zero value if the supplied
+// value is nil
+func setValue(v reflect.Value, value any) string {
+	if !v.CanSet() {
+		return fmt.Sprintf("%s cannot be set", v.Type())
+	}
+	if value == nil {
+		v.Set(reflect.Zero(v.Type()))
+		return ""
+	}
+	rv := reflect.ValueOf(value)
+	if !rv.Type().AssignableTo(v.Type()) {
+		return fmt.Sprintf(
+			"value of type %s is not assignable to %s", rv.Type(), v.Type(),
+		)
+	}
+	v.Set(rv)
+	return ""
+}
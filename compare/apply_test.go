@@ -0,0 +1,195 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/pkg/compare"
+)
+
+func TestDelta_ApplyTo_RoundTrip(t *testing.T) {
+	base := func() testResource {
+		return testResource{
+			Spec: testSpec{
+				TestMeta:          TestMeta{Owner: strP("alice")},
+				AllocatedStorage:  int64P(20),
+				AvailabilityZones: []string{"us-west-2a"},
+				Config:            &testConfig{Name: strP("cfg")},
+				Rules:             []*testRule{{Port: int64P(80)}},
+				Labels:            map[string]*string{"env": strP("dev")},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		modify func(*testResource)
+	}{
+		{"scalar", func(r *testResource) {
+			r.Spec.AllocatedStorage = int64P(40)
+			r.Spec.Engine = strP("postgres")
+		}},
+		{"promoted field", func(r *testResource) {
+			r.Spec.Owner = strP("bob")
+		}},
+		{"nested struct", func(r *testResource) {
+			r.Spec.Config.Enabled = boolP(true)
+		}},
+		{"nil struct", func(r *testResource) {
+			r.Spec.Config = nil
+		}},
+		{"list element", func(r *testResource) {
+			r.Spec.Rules[0].Protocol = strP("tcp")
+		}},
+		{"lists", func(r *testResource) {
+			r.Spec.Rules = nil
+			r.Spec.AvailabilityZones = []string{"us-west-2b", "us-west-2c"}
+		}},
+		{"map keys", func(r *testResource) {
+			r.Spec.Labels["env"] = strP("prod")
+			r.Spec.Labels["owner"] = strP("ack")
+		}},
+		{"nil map", func(r *testResource) {
+			r.Spec.Labels = nil
+		}},
+	}
+
+	for _, tc := range testCases {
+		a := base()
+		b := base()
+		tc.modify(&b)
+		delta, err := compare.Diff(a, b)
+		require.NoError(t, err)
+
+		target := base()
+		require.NoError(t, delta.ApplyTo(&target, compare.SideB))
+		assert.Equal(t, b, target, fmt.Sprintf("for %s applying side B", tc.name))
+
+		target = base()
+		tc.modify(&target)
+		require.NoError(t, delta.ApplyTo(&target, compare.SideA))
+		assert.Equal(t, a, target, fmt.Sprintf("for %s applying side A", tc.name))
+	}
+}
+
+func TestDelta_ApplyTo_InitializesIntermediates(t *testing.T) {
+	require := require.New(t)
+
+	delta := compare.NewDelta()
+	delta.Add("Spec.Config.Name", nil, strP("cfg"))
+	delta.Add("Spec.Labels.env", nil, strP("dev"))
+
+	target := testResource{}
+	require.NoError(delta.ApplyTo(&target, compare.SideB))
+	require.Equal(&testConfig{Name: strP("cfg")}, target.Spec.Config)
+	require.Equal(map[string]*string{"env": strP("dev")}, target.Spec.Labels)
+
+	// Applying the A side removes the map entry and zeroes the field
+	require.NoError(delta.ApplyTo(&target, compare.SideA))
+	require.Equal(&testConfig{}, target.Spec.Config)
+	require.Equal(map[string]*string{}, target.Spec.Labels)
+}
+
+func TestDelta_ApplyTo_UntypedMap(t *testing.T) {
+	require := require.New(t)
+
+	delta := compare.NewDelta()
+	delta.Add("Statement[0].Effect", "Deny", "Allow")
+	delta.Add("Metadata.Owner", nil, "alice")
+	delta.Add("Version", "2008-10-17", nil)
+
+	target := map[string]interface{}{
+		"Version": "2008-10-17",
+		"Statement": []interface{}{
+			map[string]interface{}{"Effect": "Deny"},
+		},
+	}
+	require.NoError(delta.ApplyTo(target, compare.SideB))
+	require.Equal(map[string]interface{}{
+		"Statement": []interface{}{
+			map[string]interface{}{"Effect": "Allow"},
+		},
+		"Metadata": map[string]interface{}{"Owner": "alice"},
+	}, target)
+}
+
+func TestDelta_ApplyTo_Unreachable(t *testing.T) {
+	require := require.New(t)
+
+	testCases := []struct {
+		path  string
+		value interface{}
+	}{
+		{"Spec.Missing", strP("x")},
+		{"Spec.unexported", "x"},
+		{"Spec.Rules[0].Port", int64P(80)},
+		{"Spec.AllocatedStorage", "20"},
+		{"Spec.Engine.Name", strP("x")},
+		{"Spec.AvailabilityZones.First", "x"},
+	}
+	for _, tc := range testCases {
+		delta := compare.NewDelta()
+		delta.Add(tc.path, nil, tc.value)
+		target := testResource{}
+		err := delta.ApplyTo(&target, compare.SideB)
+		var unreachable *compare.UnreachablePathError
+		require.True(errors.As(err, &unreachable), fmt.Sprintf("for path %s", tc.path))
+		require.Equal(tc.path, unreachable.Path.String())
+	}
+
+	delta := compare.NewDelta()
+	require.Error(delta.ApplyTo(testResource{}, compare.SideB))
+	require.Error(delta.ApplyTo((*testResource)(nil), compare.SideB))
+}
+
+func TestDelta_ApplyTo_Redacted(t *testing.T) {
+	require := require.New(t)
+
+	a := testResource{Spec: testSpec{Engine: strP("postgres")}}
+	b := testResource{Spec: testSpec{
+		Engine: strP("postgres"),
+		Config: &testConfig{Name: strP("hunter2")},
+	}}
+	delta, err := compare.Diff(a, b, compare.SensitivePaths("Spec.Config"))
+	require.NoError(err)
+
+	// The real values of a sensitive Difference are applied
+	target := a
+	require.NoError(delta.ApplyTo(&target, compare.SideB))
+	require.Equal(b, target)
+
+	// ... but not the redacted values of a decoded one
+	encoded, err := json.Marshal(delta)
+	require.NoError(err)
+	var decoded compare.Delta
+	require.NoError(json.Unmarshal(encoded, &decoded))
+	target = a
+	err = decoded.ApplyTo(&target, compare.SideB)
+	var unreachable *compare.UnreachablePathError
+	require.True(errors.As(err, &unreachable))
+	require.Equal("Spec.Config", unreachable.Path.String())
+	require.Equal(a, target)
+
+	// A nil value was not redacted, so it is applied
+	target = b
+	require.NoError(decoded.ApplyTo(&target, compare.SideA))
+	require.Equal(a, target)
+}
@@ -0,0 +1,141 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Comparer compares two values for semantic equality, e.g. two JSON policy
+// documents or two ARNs, in place of the structural comparison done by Diff.
+type Comparer interface {
+	// Equal returns true if the supplied values, which are of the same type,
+	// are equal
+	Equal(a, b any) bool
+}
+
+// ComparerFunc is a function implementing Comparer
+type ComparerFunc func(a, b any) bool
+
+// Equal implements Comparer
+func (f ComparerFunc) Equal(a, b any) bool {
+	return f(a, b)
+}
+
+// pathComparer is a Comparer registered for a path pattern
+type pathComparer struct {
+	pattern  pathPattern
+	comparer Comparer
+}
+
+// Comparers is a registry of Comparers keyed by Go type or path pattern. It
+// is safe for concurrent use.
+type Comparers struct {
+	mu     sync.RWMutex
+	byType map[reflect.Type]Comparer
+	byPath []pathComparer
+}
+
+// NewComparers returns a new, empty Comparers registry
+func NewComparers() *Comparers {
+	return &Comparers{byType: map[reflect.Type]Comparer{}}
+}
+
+// DefaultComparers is the registry consulted by every call to Diff, after any
+// registry supplied using WithComparers.
+var DefaultComparers = NewComparers()
+
+// RegisterType registers a Comparer for values of the supplied type, and
+// pointers to them. For example:
+//
+//	comparers.RegisterType(
+//	    reflect.TypeOf(resource.Quantity{}),
+//	    compare.ComparerFunc(func(a, b any) bool {
+//	        return a.(resource.Quantity).Cmp(b.(resource.Quantity)) == 0
+//	    }),
+//	)
+//
+// Registering a Comparer for a type that already has one replaces it.
+func (c *Comparers) RegisterType(t reflect.Type, comparer Comparer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.byType[t] = comparer
+}
+
+// RegisterPath registers a Comparer for the values at the supplied
+// dotted-notation path pattern, using the same syntax as IgnorePaths. For
+// example:
+//
+//	comparers.RegisterPath("Spec.PolicyDocument", jsonComparer)
+//	comparers.RegisterPath("Spec.Statements[*].Principal", arnComparer)
+//
+// Comparers registered for a path take precedence over those registered for
+// a type, and are consulted in the order they were registered.
+func (c *Comparers) RegisterPath(pattern string, comparer Comparer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.byPath = append(c.byPath, pathComparer{newPathPattern(pattern), comparer})
+}
+
+// lookup returns the Comparer registered for the supplied path or type, or
+// nil if there is none
+func (c *Comparers) lookup(path []pathPart, t reflect.Type) Comparer {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if len(c.byPath) > 0 {
+		parts := partNames(path)
+		for _, pc := range c.byPath {
+			if pc.pattern.matches(parts) {
+				return pc.comparer
+			}
+		}
+	}
+	for ; t != nil; t = derefType(t) {
+		if comparer, ok := c.byType[t]; ok {
+			return comparer
+		}
+	}
+	return nil
+}
+
+// derefType returns the element type of the supplied pointer type, or nil
+// for any other type
+func derefType(t reflect.Type) reflect.Type {
+	if t.Kind() != reflect.Ptr {
+		return nil
+	}
+	return t.Elem()
+}
+
+// RegisterTypeComparer registers a Comparer for values of the supplied type
+// with DefaultComparers. See Comparers.RegisterType.
+func RegisterTypeComparer(t reflect.Type, comparer Comparer) {
+	DefaultComparers.RegisterType(t, comparer)
+}
+
+// RegisterPathComparer registers a Comparer for the values at the supplied
+// path pattern with DefaultComparers. See Comparers.RegisterPath.
+func RegisterPathComparer(pattern string, comparer Comparer) {
+	DefaultComparers.RegisterPath(pattern, comparer)
+}
+
+// WithComparers consults the supplied registry of Comparers, before
+// DefaultComparers, when comparing values. Registries supplied by multiple
+// WithComparers Options are consulted in the order they were supplied.
+func WithComparers(comparers *Comparers) Option {
+	return func(o *options) {
+		o.comparers = append(o.comparers, comparers)
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/pkg/compare"
+)
+
+type testARN string
+
+type testPolicy struct {
+	PolicyDocument *string
+	Description    *string
+	RoleARN        *testARN
+	Principals     []testARN
+	Created        time.Time
+}
+
+// jsonComparer compares strings as JSON documents
+var jsonComparer = compare.ComparerFunc(func(a, b any) bool {
+	var da, db interface{}
+	if json.Unmarshal([]byte(a.(string)), &da) != nil ||
+		json.Unmarshal([]byte(b.(string)), &db) != nil {
+		return a == b
+	}
+	return reflect.DeepEqual(da, db)
+})
+
+func TestDiff_Comparers(t *testing.T) {
+	require := require.New(t)
+
+	a := testPolicy{
+		PolicyDocument: strP(`{"Version": "2012-10-17", "Statement": []}`),
+		Description:    strP(`{"a": 1}`),
+		Created:        time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	b := testPolicy{
+		PolicyDocument: strP(`{"Statement":[],"Version":"2012-10-17"}`),
+		Description:    strP(`{"a":1}`),
+		Created:        time.Date(2024, 1, 1, 0, 0, 0, 500, time.UTC),
+	}
+
+	delta, err := compare.Diff(a, b)
+	require.NoError(err)
+	require.Len(delta.Differences, 3)
+
+	comparers := compare.NewComparers()
+	comparers.RegisterPath("PolicyDocument", jsonComparer)
+	comparers.RegisterType(
+		reflect.TypeOf(time.Time{}),
+		compare.ComparerFunc(func(a, b any) bool {
+			return a.(time.Time).Truncate(time.Second).Equal(
+				b.(time.Time).Truncate(time.Second),
+			)
+		}),
+	)
+	delta, err = compare.Diff(a, b, compare.WithComparers(comparers))
+	require.NoError(err)
+	require.Len(delta.Differences, 1)
+	require.True(delta.DifferentAt("Description"))
+	// The recorded values are the compared pointers
+	require.Equal(a.Description, delta.Differences[0].A)
+
+	// The Comparer finds a real difference
+	b.PolicyDocument = strP(`{"Version": "2008-10-17", "Statement": []}`)
+	delta, err = compare.Diff(a, b, compare.WithComparers(comparers))
+	require.NoError(err)
+	require.True(delta.DifferentAt("PolicyDocument"))
+
+	// Nil values are not passed to a Comparer
+	b.PolicyDocument = nil
+	delta, err = compare.Diff(a, b, compare.WithComparers(comparers))
+	require.NoError(err)
+	require.True(delta.DifferentAt("PolicyDocument"))
+	a.PolicyDocument = nil
+	delta, err = compare.Diff(a, b, compare.WithComparers(comparers))
+	require.NoError(err)
+	require.False(delta.DifferentAt("PolicyDocument"))
+}
+
+func TestDiff_Comparers_Precedence(t *testing.T) {
+	require := require.New(t)
+
+	alwaysEqual := compare.ComparerFunc(func(a, b any) bool { return true })
+	neverEqual := compare.ComparerFunc(func(a, b any) bool { return false })
+
+	a := testPolicy{Description: strP("a"), Principals: []testARN{"x"}}
+	b := testPolicy{Description: strP("a"), Principals: []testARN{"x"}}
+
+	comparers := compare.NewComparers()
+	comparers.RegisterType(reflect.TypeOf(""), neverEqual)
+	comparers.RegisterPath("Description", alwaysEqual)
+	comparers.RegisterPath("Principals", neverEqual)
+	comparers.RegisterPath("Principals", alwaysEqual)
+	delta, err := compare.Diff(a, b, compare.WithComparers(comparers))
+	require.NoError(err)
+	require.True(delta.DifferentAt("Principals"))
+	require.False(delta.DifferentAt("Description"))
+
+	// Registries are consulted in the order they were supplied
+	override := compare.NewComparers()
+	override.RegisterPath("Description", neverEqual)
+	delta, err = compare.Diff(
+		a, b,
+		compare.WithComparers(override), compare.WithComparers(comparers),
+	)
+	require.NoError(err)
+	require.True(delta.DifferentAt("Description"))
+}
+
+func TestRegisterTypeComparer(t *testing.T) {
+	require := require.New(t)
+
+	compare.RegisterTypeComparer(
+		reflect.TypeOf(testARN("")),
+		compare.ComparerFunc(func(a, b any) bool {
+			return strings.EqualFold(string(a.(testARN)), string(b.(testARN)))
+		}),
+	)
+
+	arnA := testARN("arn:aws:iam::123456789012:role/Admin")
+	arnB := testARN("ARN:AWS:IAM::123456789012:ROLE/ADMIN")
+	a := testPolicy{RoleARN: &arnA, Principals: []testARN{arnA}}
+	b := testPolicy{RoleARN: &arnB, Principals: []testARN{arnB}}
+
+	delta, err := compare.Diff(a, b)
+	require.NoError(err)
+	require.Empty(delta.Differences)
+}
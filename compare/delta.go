@@ -23,19 +23,97 @@ type Delta struct {
 	Differences []*Difference
 }
 
-// DifferentAt returns whether there is a difference at the supplied JSONPath
-// expression in the resources under comparison
+// DifferentAt returns whether there is a difference at or beneath the
+// supplied dotted-notation path in the resources under comparison. A "*" part
+// matches any single field name, map key or list element and a "[*]" part
+// matches any list element, e.g. "Spec.Tags.*" or "Spec.Rules[*].Port". An
+// empty path only matches a difference between the compared values as a
+// whole, e.g. when one of them is nil.
 func (d *Delta) DifferentAt(subject string) bool {
+	pattern := newPathPattern(subject)
 	for _, diff := range d.Differences {
-		if diff.Path.Contains(subject) {
+		if subjectCovers(pattern, diff.Path) {
 			return true
 		}
 	}
 	return false
 }
 
-// DifferentExcept returns true if the delta contains any differences *other*
-// than any of the supplied path strings.
+// DifferencesAt returns a Delta containing the differences at or beneath the
+// supplied dotted-notation path pattern, using the same syntax as
+// DifferentAt. For example, an update hook may sync tag changes separately:
+//
+//	if tagDelta := delta.DifferencesAt("Spec.Tags"); len(tagDelta.Differences) > 0 {
+//	    err = rm.syncTags(ctx, desired, latest, tagDelta)
+//	    ...
+//	}
+func (d *Delta) DifferencesAt(subject string) *Delta {
+	pattern := newPathPattern(subject)
+	return d.Filter(func(diff *Difference) bool {
+		return subjectCovers(pattern, diff.Path)
+	})
+}
+
+// subjectCovers returns true if the supplied pattern, parsed from a path
+// supplied to DifferentAt, DifferencesAt or DifferentExcept, covers the
+// supplied Path. Unlike the patterns supplied as Options, an empty pattern
+// only covers the root Path rather than every Path.
+func subjectCovers(pattern pathPattern, p Path) bool {
+	if pattern.isRoot() {
+		return p.isRoot()
+	}
+	return pattern.covers(p.parts)
+}
+
+// Filter returns a Delta containing the differences for which the supplied
+// function returns true, in their original order
+func (d *Delta) Filter(keep func(*Difference) bool) *Delta {
+	filtered := NewDelta()
+	for _, diff := range d.Differences {
+		if keep(diff) {
+			filtered.Differences = append(filtered.Differences, diff)
+		}
+	}
+	return filtered
+}
+
+// Added returns a Delta containing the differences of kind KindAdded, i.e.
+// the values set only in the second resource under comparison
+func (d *Delta) Added() *Delta {
+	return d.Filter(func(diff *Difference) bool {
+		return diff.kind() == KindAdded
+	})
+}
+
+// Removed returns a Delta containing the differences of kind KindRemoved,
+// i.e. the values set only in the first resource under comparison
+func (d *Delta) Removed() *Delta {
+	return d.Filter(func(diff *Difference) bool {
+		return diff.kind() == KindRemoved
+	})
+}
+
+// Modified returns a Delta containing the differences of kind KindModified or
+// KindTypeChanged, i.e. the values set in both resources under comparison
+func (d *Delta) Modified() *Delta {
+	return d.Filter(func(diff *Difference) bool {
+		kind := diff.kind()
+		return kind == KindModified || kind == KindTypeChanged
+	})
+}
+
+// Paths returns the Path of each difference, in order
+func (d *Delta) Paths() []Path {
+	paths := make([]Path, len(d.Differences))
+	for x, diff := range d.Differences {
+		paths[x] = diff.Path
+	}
+	return paths
+}
+
+// DifferentExcept returns true if the delta contains any difference that is
+// not at or beneath any of the supplied path strings, which may contain
+// wildcards as described on DifferentAt.
 //
 // This method is useful if you have a scenario where you don't want to proceed
 // with a modification action if certain fields in a resource have not been
@@ -43,16 +121,16 @@ func (d *Delta) DifferentAt(subject string) bool {
 //
 // For example, consider this code:
 //
-// if delta.DifferentAt("Spec.Tags") {
-//     if err = rm.SyncTags(ctx, desired, latest); err != nil {
-//         return nil, err
-//     }
-// }
-// if !delta.DifferentExcept("Spec.Tags") {
-//     // We don't want to proceed to call the ModifyDBInstance API since
-//     // no other resource fields have changed.
-//     return desired, nil
-// }
+//	if delta.DifferentAt("Spec.Tags") {
+//	    if err = rm.SyncTags(ctx, desired, latest); err != nil {
+//	        return nil, err
+//	    }
+//	}
+//	if !delta.DifferentExcept("Spec.Tags") {
+//	    // We don't want to proceed to call the ModifyDBInstance API since
+//	    // no other resource fields have changed.
+//	    return desired, nil
+//	}
 //
 // might be placed in an sdk_update_pre_build_request custom code hook to
 // prevent the ModifyDBInstance call from being executed if the DBInstance's
@@ -60,21 +138,29 @@ func (d *Delta) DifferentAt(subject string) bool {
 func (d *Delta) DifferentExcept(
 	exceptPaths ...string,
 ) bool {
-	numDiffs := len(d.Differences)
-	if numDiffs == 0 {
-		return false
-	} else if numDiffs > len(exceptPaths) {
-		return true
-	}
-	foundExcepts := 0
+	excepts := newPathPatterns(exceptPaths)
 	for _, diff := range d.Differences {
-		for _, exceptPath := range exceptPaths {
-			if diff.Path.Contains(exceptPath) {
-				foundExcepts++
+		covered := false
+		for _, except := range excepts {
+			if subjectCovers(except, diff.Path) {
+				covered = true
+				break
 			}
 		}
+		if !covered {
+			return true
+		}
 	}
-	return foundExcepts != numDiffs
+	return false
+}
+
+// DifferentOnlyAt returns true if the delta contains at least one difference,
+// and every difference is at or beneath one of the supplied path strings,
+// which may contain wildcards as described on DifferentAt. For example,
+// DifferentOnlyAt("Spec.Tags") returns true if the Tags field is the only
+// field with changes.
+func (d *Delta) DifferentOnlyAt(paths ...string) bool {
+	return len(d.Differences) > 0 && !d.DifferentExcept(paths...)
 }
 
 // Add adds a new Difference to the Delta
@@ -85,7 +171,7 @@ func (d *Delta) Add(
 ) {
 	d.Differences = append(
 		d.Differences,
-		&Difference{NewPath(path), a, b},
+		&Difference{Path: NewPath(path), A: a, B: b, Kind: kindOf(a, b)},
 	)
 }
 
@@ -52,6 +52,8 @@ func TestDifferentAt(t *testing.T) {
 
 	d = compare.NewDelta()
 	d.Add("Bar", a.Bar, b.Bar)
+	require.False(d.DifferentAt("")) // only matches a root difference
+	require.Empty(d.DifferencesAt("").Differences)
 	require.True(d.DifferentAt("Bar"))
 	require.False(d.DifferentAt("Baz")) // diff exists but was not added to Delta
 
@@ -90,3 +92,147 @@ func TestDifferentExcept(t *testing.T) {
 	require.True(d.DifferentExcept("Bar"))    // there is a difference that is *not* Bar
 	require.False(d.DifferentExcept("Baz.Y")) // there is *not* a different that is *not* Bar
 }
+
+func TestDifferentAt_Wildcards(t *testing.T) {
+	require := require.New(t)
+
+	d := compare.NewDelta()
+	d.Add("Spec.Tags.env.Value", "dev", "prod")
+	d.Add("Spec.Rules[1].Port", 80, 443)
+
+	require.True(d.DifferentAt("Spec.Tags.*"))
+	require.True(d.DifferentAt("Spec.Tags.*.Value"))
+	require.True(d.DifferentAt("Spec.*.env"))
+	require.False(d.DifferentAt("Spec.Tags.*.Key"))
+	require.True(d.DifferentAt("Spec.Rules[*]"))
+	require.True(d.DifferentAt("Spec.Rules[*].Port"))
+	require.True(d.DifferentAt("Spec.Rules.*.Port"))
+	require.False(d.DifferentAt("Spec.Rules[*].Protocol"))
+	require.False(d.DifferentAt("Spec.Rules[0]"))
+	require.False(d.DifferentAt("Spec.Tags[*]"))
+	require.False(d.DifferentAt("*.*.*.*.*"))
+}
+
+func TestDifferencesAt(t *testing.T) {
+	require := require.New(t)
+
+	d := compare.NewDelta()
+	d.Add("Spec.Config.Name", "a", "b")
+	d.Add("Spec.Tags.env", "dev", "prod")
+	d.Add("Spec.Config.Enabled", true, false)
+	d.Add("Spec.Tags.owner", nil, "alice")
+
+	configDelta := d.DifferencesAt("Spec.Config")
+	require.Len(configDelta.Differences, 2)
+	require.Equal(d.Differences[0], configDelta.Differences[0])
+	require.Equal(d.Differences[2], configDelta.Differences[1])
+
+	require.Len(d.DifferencesAt("Spec.Tags.*").Differences, 2)
+	require.Len(d.DifferencesAt("Spec").Differences, 4)
+	require.Empty(d.DifferencesAt("Status").Differences)
+
+	added := d.Filter(func(diff *compare.Difference) bool {
+		return diff.A == nil
+	})
+	require.Len(added.Differences, 1)
+	require.Equal("Spec.Tags.owner", added.Differences[0].Path.String())
+
+	paths := []string{}
+	for _, p := range d.Paths() {
+		paths = append(paths, p.String())
+	}
+	require.Equal([]string{
+		"Spec.Config.Name", "Spec.Tags.env",
+		"Spec.Config.Enabled", "Spec.Tags.owner",
+	}, paths)
+	require.Empty(compare.NewDelta().Paths())
+}
+
+func TestDifferentExcept_Table(t *testing.T) {
+	require := require.New(t)
+
+	testCases := []struct {
+		name            string
+		paths           []string
+		except          []string
+		differentExcept bool
+		differentOnlyAt bool
+	}{
+		{"no differences", nil, []string{"Spec.Tags"}, false, false},
+		{"no except paths", []string{"Spec.Name"}, nil, true, false},
+		{
+			"all differences beneath except path",
+			[]string{"Spec.Tags.env", "Spec.Tags.team", "Spec.Tags.owner"},
+			[]string{"Spec.Tags"},
+			false, true,
+		},
+		{
+			"one difference outside except path",
+			[]string{"Spec.Tags.env", "Spec.Tags.team", "Spec.Name"},
+			[]string{"Spec.Tags"},
+			true, false,
+		},
+		{
+			"fewer differences than except paths",
+			[]string{"Spec.Name"},
+			[]string{"Spec.Tags", "Spec.Config"},
+			true, false,
+		},
+		{
+			"overlapping except paths",
+			[]string{"Spec.Tags.env", "Spec.Name"},
+			[]string{"Spec.Tags", "Spec.Tags.env"},
+			true, false,
+		},
+		{
+			"overlapping except paths covering every difference",
+			[]string{"Spec.Tags.env", "Spec.Config.Name"},
+			[]string{"Spec.Tags", "Spec.Tags.env", "Spec.Config"},
+			false, true,
+		},
+		{
+			"except path beneath difference",
+			[]string{"Spec.Config"},
+			[]string{"Spec.Config.Name"},
+			true, false,
+		},
+		{
+			"except path is a prefix of the field name only",
+			[]string{"Spec.TagsExtra"},
+			[]string{"Spec.Tags"},
+			true, false,
+		},
+		{
+			"nested list elements",
+			[]string{"Spec.Rules[0].Port", "Spec.Rules[1].Protocol"},
+			[]string{"Spec.Rules"},
+			false, true,
+		},
+		{
+			"wildcard except paths",
+			[]string{"Spec.Rules[0].Port", "Spec.Rules[1].Port", "Spec.Rules[1].Protocol"},
+			[]string{"Spec.Rules[*].Port"},
+			true, false,
+		},
+		{
+			"root except path only covers a root difference",
+			[]string{"Spec.Name", "Status.Phase"},
+			[]string{""},
+			true, false,
+		},
+		{
+			"root except path with a root difference",
+			[]string{""},
+			[]string{""},
+			false, true,
+		},
+	}
+	for _, tc := range testCases {
+		d := compare.NewDelta()
+		for _, path := range tc.paths {
+			d.Add(path, "a", "b")
+		}
+		require.Equal(tc.differentExcept, d.DifferentExcept(tc.except...), tc.name)
+		require.Equal(tc.differentOnlyAt, d.DifferentOnlyAt(tc.except...), tc.name)
+	}
+}
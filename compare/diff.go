@@ -0,0 +1,637 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// pathPart is a part of the Path to a value being walked by Diff, along with
+// the corresponding JSON object member name or array index
+type pathPart struct {
+	name string
+	json string
+}
+
+// Diff walks the supplied values, which must be of the same type, and returns
+// a Delta containing a Difference for each leaf value that differs between
+// them. Structs, pointers, interfaces, slices, arrays and maps are walked
+// recursively. The semantics match those of the code generated for ACK
+// controllers, so that generated code may be replaced incrementally:
+//
+//   - If exactly one of two pointers, interfaces, slices or maps is nil (see
+//     HasNilDifference), a single Difference is recorded for the field, even
+//     if the non-nil value is empty. Two nil values are equal. Nil values are
+//     never dereferenced.
+//   - Structs with an Equal method, e.g. time.Time, metav1.Time or
+//     resource.Quantity, are compared using that method, as generated code
+//     does, and any difference is recorded for the whole struct.
+//   - Exported struct fields are compared one by one, and each is recorded
+//     using its Go field name, e.g. "Spec.AllocatedStorage". Fields of
+//     embedded structs are promoted, so no Path part is added for the
+//     embedded struct itself. Unexported fields are compared using
+//     reflect.DeepEqual, and a difference in them is recorded for the whole
+//     struct if its exported fields are equal.
+//   - Pointers to scalar values are compared by the value they point to, and
+//     the pointers themselves are recorded as the Difference's A and B.
+//   - Slices and arrays of scalar values (or pointers to scalar values) are
+//     compared regardless of order, as SliceStringPEqual does, and any
+//     difference is recorded for the whole slice. Byte slices are compared
+//     in order.
+//   - Other slices are compared element by element if they have the same
+//     length, recording element Paths such as "Spec.Rules[0].Port", and as a
+//     whole otherwise.
+//   - Map values are compared key by key, using the key as the Path part,
+//     e.g. "Spec.Tags.env". A key present in only one map is recorded with a
+//     nil value for the other side.
+//   - Channels and functions are compared for nilness only.
+//   - Pointers, maps and slices reached again through a cycle, e.g. a struct
+//     pointing to itself, are not walked again.
+//
+// The supplied Options, e.g. IgnorePaths or EquateEmpty, alter these
+// semantics.
+//
+// An error is returned if the supplied values are of different types.
+func Diff(a, b any, opts ...Option) (*Delta, error) {
+	d := newDiffer(newOptions(opts))
+	va := reflect.ValueOf(a)
+	vb := reflect.ValueOf(b)
+	if !va.IsValid() || !vb.IsValid() {
+		if HasNilDifference(a, b) {
+			d.add(d.opts.prefix, a, b)
+		}
+		return d.delta, nil
+	}
+	if va.Type() != vb.Type() {
+		return nil, fmt.Errorf(
+			"cannot diff values of different types %s and %s",
+			va.Type(), vb.Type(),
+		)
+	}
+	d.diff(d.opts.prefix, va, vb)
+	return d.delta, nil
+}
+
+// differ walks two values and records their differences in a Delta
+type differ struct {
+	opts  *options
+	delta *Delta
+	// equalOpts are the options used to compare scalar values in equal
+	equalOpts *options
+	// embedded is true if the differ is walking the documents held by string
+	// fields, see EmbeddedDocuments
+	embedded bool
+	// visiting contains the pointers, maps and slices currently being walked,
+	// so that cycles, e.g. a struct pointing to itself, are walked only once
+	visiting map[visit]bool
+}
+
+// visit identifies a pair of pointers, maps or slices being walked by a differ
+type visit struct {
+	a, b       uintptr
+	lenA, lenB int
+	typ        reflect.Type
+}
+
+// newDiffer returns a differ using the supplied options
+func newDiffer(opts *options) *differ {
+	equalOpts := *opts
+	// A set scalar value is never unset, even if it's the zero value
+	equalOpts.ignoreUnsetInDesired = false
+	return &differ{
+		opts:      opts,
+		delta:     NewDelta(),
+		equalOpts: &equalOpts,
+		visiting:  map[visit]bool{},
+	}
+}
+
+// add records a Difference at the supplied path
+func (d *differ) add(path []pathPart, a, b any) {
+	parts := make([]string, len(path))
+	jsonPath := make([]string, len(path))
+	for x, part := range path {
+		parts[x] = part.name
+		jsonPath[x] = part.json
+	}
+	if len(parts) == 0 {
+		parts = []string{""}
+	}
+	d.delta.Differences = append(
+		d.delta.Differences,
+		&Difference{
+			Path:      Path{parts},
+			A:         a,
+			B:         b,
+			Kind:      kindOf(a, b),
+			Sensitive: d.opts.isSensitive(path, a, b),
+			Embedded:  d.embedded,
+			jsonPath:  jsonPath,
+		},
+	)
+}
+
+// addValues records a Difference at the supplied path for the supplied
+// reflected values
+func (d *differ) addValues(path []pathPart, a, b reflect.Value) {
+	d.add(path, interfaceOf(a), interfaceOf(b))
+}
+
+// equal returns true if Diff would find no differences between the supplied
+// values of the same type at the supplied path
+func (d *differ) equal(path []pathPart, a, b reflect.Value) bool {
+	sub := &differ{
+		opts:      d.equalOpts,
+		delta:     NewDelta(),
+		equalOpts: d.equalOpts,
+		visiting:  d.visiting,
+	}
+	sub.diff(path, a, b)
+	return len(sub.delta.Differences) == 0
+}
+
+// comparer returns the Comparer for values of the supplied type at the
+// supplied path, or nil if structural comparison should be used
+func (d *differ) comparer(path []pathPart, t reflect.Type) Comparer {
+	for _, comparers := range d.opts.comparers {
+		if comparer := comparers.lookup(path, t); comparer != nil {
+			return comparer
+		}
+	}
+	return DefaultComparers.lookup(path, t)
+}
+
+// compare records a difference at the supplied path if the supplied Comparer
+// finds the supplied values unequal. Pointers and interfaces are dereferenced
+// before the values are passed to the Comparer, and a nil value differs from
+// a non-nil one, as do interfaces holding values of different types.
+func (d *differ) compare(
+	path []pathPart,
+	comparer Comparer,
+	a, b reflect.Value,
+) {
+	ea, eb := a, b
+	for ea.Kind() == reflect.Ptr || ea.Kind() == reflect.Interface {
+		if ea.IsNil() || eb.IsNil() {
+			if ea.IsNil() != eb.IsNil() {
+				d.addValues(path, a, b)
+			}
+			return
+		}
+		ea, eb = ea.Elem(), eb.Elem()
+		if ea.Type() != eb.Type() {
+			d.addValues(path, a, b)
+			return
+		}
+	}
+	if !comparer.Equal(ea.Interface(), eb.Interface()) {
+		d.addValues(path, a, b)
+	}
+}
+
+// ignored returns true if differences at the supplied path are ignored
+func (d *differ) ignored(path []pathPart) bool {
+	return len(d.opts.ignore) > 0 && anyCovers(d.opts.ignore, partNames(path))
+}
+
+// ignoresCase returns true if strings at the supplied path are compared
+// case-insensitively
+func (d *differ) ignoresCase(path []pathPart) bool {
+	return len(d.opts.ignoreCase) > 0 &&
+		anyCovers(d.opts.ignoreCase, partNames(path))
+}
+
+// isUnordered returns true if lists at the supplied path are compared
+// regardless of order
+func (d *differ) isUnordered(path []pathPart) bool {
+	return len(d.opts.unordered) > 0 &&
+		anyCovers(d.opts.unordered, partNames(path))
+}
+
+// nilEqualsZero returns true if the supplied pointers, exactly one of which is
+// nil, are equal because the other points to the zero value
+func (d *differ) nilEqualsZero(a, b reflect.Value) bool {
+	if !d.opts.nilEqualsZero {
+		return false
+	}
+	if a.IsNil() {
+		return b.Elem().IsZero()
+	}
+	return a.Elem().IsZero()
+}
+
+// nilDifference returns true if exactly one of the supplied slice or map
+// values is nil, and they are not both empty when empty values are equated
+func (d *differ) nilDifference(a, b reflect.Value) bool {
+	if a.IsNil() == b.IsNil() {
+		return false
+	}
+	return !d.opts.equateEmpty || a.Len() != 0 || b.Len() != 0
+}
+
+// diff records the differences between the supplied values, which must be of
+// the same type, at the supplied path
+func (d *differ) diff(path []pathPart, a, b reflect.Value) {
+	if d.ignored(path) || (d.opts.ignoreUnsetInDesired && a.IsZero()) {
+		return
+	}
+	if comparer := d.comparer(path, a.Type()); comparer != nil {
+		d.compare(path, comparer, a, b)
+		return
+	}
+	if d.isDocument(path) && d.diffDocuments(path, a, b) {
+		return
+	}
+	if v, ok := visitOf(a, b); ok {
+		// A cycle has been reached, and the values are compared where it
+		// was entered, as reflect.DeepEqual does
+		if d.visiting[v] {
+			return
+		}
+		d.visiting[v] = true
+		defer delete(d.visiting, v)
+	}
+	switch a.Kind() {
+	case reflect.Ptr:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() && !d.nilEqualsZero(a, b) {
+				d.addValues(path, a, b)
+			}
+			return
+		}
+		if a.Pointer() == b.Pointer() {
+			return
+		}
+		if isComposite(a.Type().Elem()) {
+			d.diff(path, a.Elem(), b.Elem())
+			return
+		}
+		if !d.equal(path, a.Elem(), b.Elem()) {
+			d.addValues(path, a, b)
+		}
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				d.addValues(path, a, b)
+			}
+			return
+		}
+		ea, eb := a.Elem(), b.Elem()
+		if ea.Type() != eb.Type() {
+			d.addValues(path, a, b)
+			return
+		}
+		d.diff(path, ea, eb)
+	case reflect.Struct:
+		d.diffStruct(path, a, b)
+	case reflect.Slice:
+		if a.IsNil() || b.IsNil() {
+			if d.nilDifference(a, b) {
+				d.addValues(path, a, b)
+			}
+			return
+		}
+		d.diffList(path, a, b)
+	case reflect.Array:
+		d.diffList(path, a, b)
+	case reflect.Map:
+		if a.IsNil() || b.IsNil() {
+			if d.nilDifference(a, b) {
+				d.addValues(path, a, b)
+			}
+			return
+		}
+		d.diffMap(path, a, b)
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		if a.IsNil() != b.IsNil() {
+			d.addValues(path, a, b)
+		}
+	case reflect.String:
+		if d.ignoresCase(path) {
+			if !strings.EqualFold(a.String(), b.String()) {
+				d.addValues(path, a, b)
+			}
+			return
+		}
+		if !a.Equal(b) {
+			d.addValues(path, a, b)
+		}
+	default:
+		if !a.Equal(b) {
+			d.addValues(path, a, b)
+		}
+	}
+}
+
+// visitOf returns the visit for the supplied values if they are non-nil
+// pointers, maps or slices, which may be reached again through a cycle
+func visitOf(a, b reflect.Value) (visit, bool) {
+	switch a.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if a.IsNil() || b.IsNil() {
+			return visit{}, false
+		}
+		v := visit{a: a.Pointer(), b: b.Pointer(), typ: a.Type()}
+		if a.Kind() == reflect.Slice {
+			// Slices sharing a backing array but of different lengths are
+			// different values
+			v.lenA, v.lenB = a.Len(), b.Len()
+		}
+		return v, true
+	}
+	return visit{}, false
+}
+
+// diffStruct records the differences between the supplied struct values
+func (d *differ) diffStruct(path []pathPart, a, b reflect.Value) {
+	if equal, ok := equalByMethod(a, b); ok {
+		if !equal {
+			d.addValues(path, a, b)
+		}
+		return
+	}
+	t := a.Type()
+	recorded := len(d.delta.Differences)
+	for x := 0; x < t.NumField(); x++ {
+		field := t.Field(x)
+		if !field.IsExported() {
+			continue
+		}
+		jsonName := strings.Split(field.Tag.Get("json"), ",")[0]
+		if field.Anonymous && jsonName == "" && isComposite(field.Type) {
+			// encoding/json also promotes the fields of untagged embedded
+			// structs
+			fa, fb := promoted(a.Field(x)), promoted(b.Field(x))
+			if fa.IsValid() || fb.IsValid() {
+				d.diff(path, orZero(fa, fb), orZero(fb, fa))
+			}
+			continue
+		}
+		if jsonName == "" || jsonName == "-" {
+			jsonName = field.Name
+		}
+		d.diff(
+			append(path, pathPart{field.Name, jsonName}),
+			a.Field(x), b.Field(x),
+		)
+	}
+	if len(d.delta.Differences) == recorded && !unexportedFieldsEqual(a, b) {
+		d.addValues(path, a, b)
+	}
+}
+
+// promoted returns the struct whose fields are promoted from the supplied
+// embedded field value, dereferencing pointers, or an invalid value if a nil
+// pointer is reached
+func promoted(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// orZero returns the supplied value if it is valid, and the zero value of the
+// other value's type otherwise, so that the promoted fields of a nil embedded
+// pointer are compared as unset
+func orZero(v, other reflect.Value) reflect.Value {
+	if v.IsValid() {
+		return v
+	}
+	return reflect.Zero(other.Type())
+}
+
+// equalByMethod compares the supplied values of the same struct type using
+// the type's Equal method, if it has one of the form "func (T) Equal(T) bool"
+// or "func (*T) Equal(*T) bool". It returns false for ok if there is no such
+// method.
+func equalByMethod(a, b reflect.Value) (equal bool, ok bool) {
+	t := a.Type()
+	if m, found := t.MethodByName("Equal"); found && isEqualMethod(m.Type, t) {
+		return m.Func.Call([]reflect.Value{a, b})[0].Bool(), true
+	}
+	pt := reflect.PointerTo(t)
+	if m, found := pt.MethodByName("Equal"); found && isEqualMethod(m.Type, pt) {
+		args := []reflect.Value{addressOf(a), addressOf(b)}
+		return m.Func.Call(args)[0].Bool(), true
+	}
+	return false, false
+}
+
+// isEqualMethod returns true if the supplied method type, including its
+// receiver, is that of an Equal method of the supplied receiver type
+func isEqualMethod(m reflect.Type, recv reflect.Type) bool {
+	return m.NumIn() == 2 && m.In(1) == recv &&
+		m.NumOut() == 1 && m.Out(0).Kind() == reflect.Bool
+}
+
+// addressOf returns a pointer to the supplied value, or to a copy of it if
+// the value is not addressable
+func addressOf(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v.Addr()
+	}
+	p := reflect.New(v.Type())
+	p.Elem().Set(v)
+	return p
+}
+
+// unexportedFieldsEqual returns true if the unexported fields of the supplied
+// values of the same struct type are deeply equal
+func unexportedFieldsEqual(a, b reflect.Value) bool {
+	t := a.Type()
+	if t.NumField() == 0 || hasOnlyExportedFields(t) {
+		return true
+	}
+	// Unexported fields cannot be read using reflection, so copies of the
+	// values with their exported fields zeroed are compared instead
+	ca := reflect.New(t).Elem()
+	ca.Set(a)
+	cb := reflect.New(t).Elem()
+	cb.Set(b)
+	for x := 0; x < t.NumField(); x++ {
+		if t.Field(x).IsExported() {
+			ca.Field(x).Set(reflect.Zero(t.Field(x).Type))
+			cb.Field(x).Set(reflect.Zero(t.Field(x).Type))
+		}
+	}
+	return reflect.DeepEqual(ca.Interface(), cb.Interface())
+}
+
+// diffList records the differences between the supplied slice or array
+// values
+func (d *differ) diffList(path []pathPart, a, b reflect.Value) {
+	elemType := a.Type().Elem()
+	if elemType.Kind() == reflect.Uint8 {
+		if !bytes.Equal(bytesOf(a), bytesOf(b)) {
+			d.addValues(path, a, b)
+		}
+		return
+	}
+	if !isComposite(elemType) {
+		if !d.unorderedEqual(path, a, b) {
+			d.addValues(path, a, b)
+		}
+		return
+	}
+	if keyField := d.keyField(path); keyField != "" {
+		if d.diffKeyed(path, keyField, a, b) {
+			return
+		}
+	}
+	if d.isUnordered(path) {
+		if !d.unorderedEqual(path, a, b) {
+			d.addValues(path, a, b)
+		}
+		return
+	}
+	if a.Len() != b.Len() {
+		d.addValues(path, a, b)
+		return
+	}
+	for x := 0; x < a.Len(); x++ {
+		d.diff(
+			append(path, pathPart{elementPart(x), strconv.Itoa(x)}),
+			a.Index(x), b.Index(x),
+		)
+	}
+}
+
+// unorderedEqual returns true if the supplied slices of scalars (or pointers
+// to scalars) at the supplied path contain the same elements, regardless of
+// order
+func (d *differ) unorderedEqual(path []pathPart, a, b reflect.Value) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+	matched := make([]bool, b.Len())
+	for x := 0; x < a.Len(); x++ {
+		found := false
+		for y := 0; y < b.Len(); y++ {
+			if !matched[y] && d.equal(path, a.Index(x), b.Index(y)) {
+				matched[y] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// diffMap records the differences between the values of the supplied maps,
+// visiting keys in sorted order
+func (d *differ) diffMap(path []pathPart, a, b reflect.Value) {
+	keys := map[string]reflect.Value{}
+	for _, k := range a.MapKeys() {
+		keys[mapKeyPart(k)] = k
+	}
+	for _, k := range b.MapKeys() {
+		keys[mapKeyPart(k)] = k
+	}
+	sortedKeys := make([]string, 0, len(keys))
+	for k := range keys {
+		sortedKeys = append(sortedKeys, k)
+	}
+	sort.Strings(sortedKeys)
+	for _, part := range sortedKeys {
+		k := keys[part]
+		va := a.MapIndex(k)
+		vb := b.MapIndex(k)
+		keyPath := append(path, pathPart{part, part})
+		if !va.IsValid() || !vb.IsValid() {
+			if d.ignored(keyPath) || (d.opts.ignoreUnsetInDesired && !va.IsValid()) {
+				continue
+			}
+			d.addValues(keyPath, va, vb)
+			continue
+		}
+		d.diff(keyPath, va, vb)
+	}
+}
+
+// partNames returns the names of the supplied path parts
+func partNames(path []pathPart) []string {
+	names := make([]string, len(path))
+	for x, part := range path {
+		names[x] = part.name
+	}
+	return names
+}
+
+// interfaceOf returns the value held by the supplied reflected value, or nil
+// if the reflected value is the zero Value
+func interfaceOf(v reflect.Value) any {
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// bytesOf returns the contents of the supplied byte slice or array value
+func bytesOf(v reflect.Value) []byte {
+	if v.Kind() == reflect.Slice {
+		return v.Bytes()
+	}
+	b := make([]byte, v.Len())
+	reflect.Copy(reflect.ValueOf(b), v)
+	return b
+}
+
+// isComposite returns true if values of the supplied type are walked by Diff
+// rather than compared as a whole
+func isComposite(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map, reflect.Interface:
+		return true
+	}
+	return false
+}
+
+// hasOnlyExportedFields returns true if every field of the supplied struct
+// type is exported
+func hasOnlyExportedFields(t reflect.Type) bool {
+	for x := 0; x < t.NumField(); x++ {
+		if !t.Field(x).IsExported() {
+			return false
+		}
+	}
+	return true
+}
+
+// elementPart returns the Path part selecting the slice element at the
+// supplied index, e.g. "[0]"
+func elementPart(index int) string {
+	return "[" + strconv.Itoa(index) + "]"
+}
+
+// mapKeyPart returns the Path part for the supplied map key
+func mapKeyPart(k reflect.Value) string {
+	if k.Kind() == reflect.String {
+		return k.String()
+	}
+	return fmt.Sprint(k.Interface())
+}
@@ -0,0 +1,517 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws-controllers-k8s/pkg/compare"
+)
+
+type testTag struct {
+	Key   *string
+	Value *string
+}
+
+type testRule struct {
+	Port     *int64
+	Protocol *string
+}
+
+type testConfig struct {
+	Name    *string
+	Enabled *bool
+}
+
+type TestMeta struct {
+	Owner *string
+}
+
+type testSpec struct {
+	TestMeta
+	AllocatedStorage  *int64
+	Engine            *string
+	SecurityGroupIDs  []*string
+	AvailabilityZones []string
+	Config            *testConfig
+	Rules             []*testRule
+	Tags              []*testTag
+	Labels            map[string]*string
+	Created           *time.Time
+	Data              []byte
+	Extra             interface{}
+	unexported        string
+}
+
+type testResource struct {
+	Spec testSpec
+}
+
+func strP(s string) *string {
+	return &s
+}
+
+func int64P(i int64) *int64 {
+	return &i
+}
+
+func boolP(b bool) *bool {
+	return &b
+}
+
+func TestDiff_Equal(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(5, 0)
+	newSpec := func() testSpec {
+		created := now
+		return testSpec{
+			TestMeta:          TestMeta{Owner: strP("me")},
+			AllocatedStorage:  int64P(20),
+			Engine:            strP("postgres"),
+			SecurityGroupIDs:  []*string{strP("sg-1"), strP("sg-2")},
+			AvailabilityZones: []string{"us-west-2a", "us-west-2b"},
+			Config:            &testConfig{Name: strP("cfg"), Enabled: boolP(true)},
+			Rules:             []*testRule{{Port: int64P(80), Protocol: strP("tcp")}},
+			Labels:            map[string]*string{"env": strP("prod")},
+			Created:           &created,
+			Data:              []byte("data"),
+			Extra:             map[string]interface{}{"a": []interface{}{"b", 1.0}},
+			unexported:        "a",
+		}
+	}
+	a := newSpec()
+	b := newSpec()
+	// Slices of scalars are compared regardless of order
+	b.SecurityGroupIDs = []*string{strP("sg-2"), strP("sg-1")}
+	b.AvailabilityZones = []string{"us-west-2b", "us-west-2a"}
+
+	delta, err := compare.Diff(a, b)
+	require.Nil(err)
+	require.Empty(delta.Differences)
+
+	delta, err = compare.Diff(&a, &b)
+	require.Nil(err)
+	require.Empty(delta.Differences)
+
+	delta, err = compare.Diff(nil, nil)
+	require.Nil(err)
+	require.Empty(delta.Differences)
+
+	// A difference in unexported fields is recorded for the whole struct
+	b.unexported = "b"
+	delta, err = compare.Diff(
+		testResource{Spec: a}, testResource{Spec: b},
+	)
+	require.Nil(err)
+	require.Len(delta.Differences, 1)
+	require.Equal("Spec", delta.Differences[0].Path.String())
+
+	// ... unless its exported fields differ
+	b.Engine = strP("mysql")
+	delta, err = compare.Diff(
+		testResource{Spec: a}, testResource{Spec: b},
+	)
+	require.Nil(err)
+	require.Len(delta.Differences, 1)
+	require.Equal("Spec.Engine", delta.Differences[0].Path.String())
+}
+
+func TestDiff_Scalars(t *testing.T) {
+	require := require.New(t)
+
+	a := testResource{Spec: testSpec{
+		AllocatedStorage: int64P(20),
+		Engine:           strP("postgres"),
+	}}
+	b := testResource{Spec: testSpec{
+		AllocatedStorage: int64P(50),
+		Engine:           nil,
+		Config:           &testConfig{},
+	}}
+
+	delta, err := compare.Diff(a, b)
+	require.Nil(err)
+	require.Len(delta.Differences, 3)
+
+	diff := delta.Differences[0]
+	require.Equal("Spec.AllocatedStorage", diff.Path.String())
+	// Pointers to scalars are recorded as-is
+	require.Equal(a.Spec.AllocatedStorage, diff.A)
+	require.Equal(b.Spec.AllocatedStorage, diff.B)
+
+	require.Equal("Spec.Engine", delta.Differences[1].Path.String())
+	require.Equal("Spec.Config", delta.Differences[2].Path.String())
+	require.True(delta.DifferentAt("Spec.Engine"))
+	require.True(delta.DifferentAt("Spec.Config"))
+	require.False(delta.DifferentAt("Spec.Config.Name"))
+	require.False(delta.DifferentAt("Spec.Tags"))
+
+	delta, err = compare.Diff(int64(1), int64(2))
+	require.Nil(err)
+	require.Len(delta.Differences, 1)
+	require.True(delta.DifferentAt(""))
+}
+
+func TestDiff_NestedStructs(t *testing.T) {
+	require := require.New(t)
+
+	a := testSpec{
+		TestMeta: TestMeta{Owner: strP("me")},
+		Config:   &testConfig{Name: strP("a"), Enabled: boolP(true)},
+	}
+	b := testSpec{
+		TestMeta: TestMeta{Owner: strP("you")},
+		Config:   &testConfig{Name: strP("b"), Enabled: boolP(true)},
+	}
+
+	delta, err := compare.Diff(a, b, compare.WithPathPrefix("Spec"))
+	require.Nil(err)
+	require.Len(delta.Differences, 2)
+	// Fields of embedded structs are promoted
+	require.Equal("Spec.Owner", delta.Differences[0].Path.String())
+	require.Equal("Spec.Config.Name", delta.Differences[1].Path.String())
+	require.Equal(a.Config.Name, delta.Differences[1].A)
+	require.Equal(b.Config.Name, delta.Differences[1].B)
+
+	// Fields promoted from a nil embedded pointer are unset
+	type withMetaP struct {
+		*TestMeta
+		Engine *string
+	}
+	pa := withMetaP{Engine: strP("mysql")}
+	pb := withMetaP{TestMeta: &TestMeta{Owner: strP("me")}, Engine: strP("mysql")}
+	delta, err = compare.Diff(pa, withMetaP{TestMeta: &TestMeta{}, Engine: strP("mysql")})
+	require.Nil(err)
+	require.Empty(delta.Differences)
+
+	delta, err = compare.Diff(pa, pb)
+	require.Nil(err)
+	require.Len(delta.Differences, 1)
+	require.Equal("Owner", delta.Differences[0].Path.String())
+	require.Nil(delta.Differences[0].A)
+	require.Equal(pb.Owner, delta.Differences[0].B)
+
+	patch, err := delta.JSONPatch()
+	require.Nil(err)
+	require.JSONEq(`[{"op":"add","path":"/Owner","value":"me"}]`, string(patch))
+	require.Nil(delta.ApplyTo(&pa, compare.SideB))
+	require.Equal(pb, pa)
+}
+
+func TestDiff_Slices(t *testing.T) {
+	require := require.New(t)
+
+	// Nil and empty slices are different, as with HasNilDifference
+	a := testSpec{SecurityGroupIDs: nil}
+	b := testSpec{SecurityGroupIDs: []*string{}}
+	delta, err := compare.Diff(a, b)
+	require.Nil(err)
+	require.Len(delta.Differences, 1)
+	require.Equal("SecurityGroupIDs", delta.Differences[0].Path.String())
+
+	// Slices of scalars are recorded as a whole
+	a = testSpec{SecurityGroupIDs: []*string{strP("sg-1"), strP("sg-1")}}
+	b = testSpec{SecurityGroupIDs: []*string{strP("sg-1"), strP("sg-2")}}
+	delta, err = compare.Diff(a, b)
+	require.Nil(err)
+	require.Len(delta.Differences, 1)
+	require.Equal("SecurityGroupIDs", delta.Differences[0].Path.String())
+	require.Equal(a.SecurityGroupIDs, delta.Differences[0].A)
+
+	// Slices of structs with the same length are compared element by element
+	a = testSpec{Rules: []*testRule{
+		{Port: int64P(80), Protocol: strP("tcp")},
+		{Port: int64P(443), Protocol: strP("tcp")},
+	}}
+	b = testSpec{Rules: []*testRule{
+		{Port: int64P(80), Protocol: strP("tcp")},
+		{Port: int64P(8443), Protocol: strP("tcp")},
+	}}
+	delta, err = compare.Diff(a, b, compare.WithPathPrefix("Spec"))
+	require.Nil(err)
+	require.Len(delta.Differences, 1)
+	require.Equal("Spec.Rules[1].Port", delta.Differences[0].Path.String())
+	require.True(delta.DifferentAt("Spec.Rules"))
+	require.True(delta.DifferentAt("Spec.Rules[1]"))
+	require.False(delta.DifferentAt("Spec.Rules[0]"))
+
+	// ... and as a whole otherwise
+	b.Rules = b.Rules[:1]
+	delta, err = compare.Diff(a, b, compare.WithPathPrefix("Spec"))
+	require.Nil(err)
+	require.Len(delta.Differences, 1)
+	require.Equal("Spec.Rules", delta.Differences[0].Path.String())
+
+	// Byte slices are compared in order
+	a = testSpec{Data: []byte("ab")}
+	b = testSpec{Data: []byte("ba")}
+	delta, err = compare.Diff(a, b)
+	require.Nil(err)
+	require.Len(delta.Differences, 1)
+	require.Equal("Data", delta.Differences[0].Path.String())
+}
+
+type testNode struct {
+	Name     *string
+	Next     *testNode
+	Children map[string]*testNode
+}
+
+func TestDiff_Cycles(t *testing.T) {
+	require := require.New(t)
+
+	a := &testNode{Name: strP("a")}
+	a.Next = a
+	b := &testNode{Name: strP("b")}
+	b.Next = b
+	delta, err := compare.Diff(a, b)
+	require.Nil(err)
+	require.Len(delta.Differences, 1)
+	require.Equal("Name", delta.Differences[0].Path.String())
+
+	// Cycles through maps and of different lengths
+	a = &testNode{Name: strP("a"), Children: map[string]*testNode{}}
+	a.Children["self"] = a
+	b = &testNode{Name: strP("a"), Children: map[string]*testNode{}}
+	b.Next = &testNode{Next: b}
+	b.Children["self"] = b.Next
+	delta, err = compare.Diff(a, b)
+	require.Nil(err)
+	require.Equal(
+		[]string{
+			"Next",
+			"Children.self.Name",
+			"Children.self.Next",
+			"Children.self.Children",
+		},
+		pathStrings(delta),
+	)
+
+	// Shared values that are not cycles are walked at each path
+	shared := &testNode{Name: strP("shared")}
+	a = &testNode{Next: shared, Children: map[string]*testNode{"x": shared}}
+	b = &testNode{
+		Next:     &testNode{Name: strP("other")},
+		Children: map[string]*testNode{"x": {Name: strP("other")}},
+	}
+	delta, err = compare.Diff(a, b)
+	require.Nil(err)
+	require.Equal([]string{"Next.Name", "Children.x.Name"}, pathStrings(delta))
+}
+
+func pathStrings(delta *compare.Delta) []string {
+	paths := []string{}
+	for _, p := range delta.Paths() {
+		paths = append(paths, p.String())
+	}
+	return paths
+}
+
+func TestDiff_Arrays(t *testing.T) {
+	require := require.New(t)
+
+	type arraySpec struct {
+		Zones [2]string
+	}
+	a := arraySpec{Zones: [2]string{"a", "b"}}
+	b := arraySpec{Zones: [2]string{"a", "c"}}
+	delta, err := compare.Diff(a, b)
+	require.Nil(err)
+	require.Len(delta.Differences, 1)
+	require.Equal("Zones", delta.Differences[0].Path.String())
+	require.Equal(compare.KindModified, delta.Differences[0].Kind)
+
+	// Arrays are never nil
+	delta, err = compare.Diff(nil, [2]string{})
+	require.Nil(err)
+	require.Len(delta.Differences, 1)
+	require.Equal(compare.KindAdded, delta.Differences[0].Kind)
+
+	delta = compare.NewDelta()
+	delta.Add("Zones", [2]string{}, [2]string{"a"})
+	require.Equal(compare.KindModified, delta.Differences[0].Kind)
+}
+
+func TestDiff_Maps(t *testing.T) {
+	require := require.New(t)
+
+	a := testSpec{Labels: map[string]*string{
+		"env":  strP("prod"),
+		"team": strP("a"),
+	}}
+	b := testSpec{Labels: map[string]*string{
+		"env":   strP("dev"),
+		"owner": strP("b"),
+	}}
+	delta, err := compare.Diff(a, b, compare.WithPathPrefix("Spec"))
+	require.Nil(err)
+	require.Len(delta.Differences, 3)
+	require.Equal("Spec.Labels.env", delta.Differences[0].Path.String())
+	require.Equal("Spec.Labels.owner", delta.Differences[1].Path.String())
+	require.Nil(delta.Differences[1].A)
+	require.Equal(b.Labels["owner"], delta.Differences[1].B)
+	require.Equal("Spec.Labels.team", delta.Differences[2].Path.String())
+	require.Equal(a.Labels["team"], delta.Differences[2].A)
+	require.Nil(delta.Differences[2].B)
+
+	// Nested untyped documents
+	a = testSpec{Extra: map[string]interface{}{
+		"States": map[string]interface{}{
+			"Foo": map[string]interface{}{"Type": "Task"},
+		},
+		"Count": 1.0,
+	}}
+	b = testSpec{Extra: map[string]interface{}{
+		"States": map[string]interface{}{
+			"Foo": map[string]interface{}{"Type": "Pass"},
+		},
+		"Count": "1",
+	}}
+	delta, err = compare.Diff(a, b)
+	require.Nil(err)
+	require.Len(delta.Differences, 2)
+	require.Equal("Extra.Count", delta.Differences[0].Path.String())
+	require.Equal("Extra.States.Foo.Type", delta.Differences[1].Path.String())
+}
+
+func TestDiff_Time(t *testing.T) {
+	require := require.New(t)
+
+	t1 := time.Unix(5, 0)
+	t2 := time.Unix(10, 0)
+	delta, err := compare.Diff(testSpec{Created: &t1}, testSpec{Created: &t2})
+	require.Nil(err)
+	require.Len(delta.Differences, 1)
+	require.Equal("Created", delta.Differences[0].Path.String())
+
+	// Times are compared using their Equal method, so the same instant in a
+	// different location or without a monotonic clock reading is equal
+	now := time.Now()
+	utc := now.UTC()
+	delta, err = compare.Diff(testSpec{Created: &now}, testSpec{Created: &utc})
+	require.Nil(err)
+	require.Empty(delta.Differences)
+
+	type metaSpec struct {
+		Created  *k8smetav1.Time
+		Modified k8smetav1.Time
+	}
+	delta, err = compare.Diff(
+		metaSpec{Created: &k8smetav1.Time{Time: now}, Modified: k8smetav1.Time{Time: now}},
+		metaSpec{Created: &k8smetav1.Time{Time: utc}, Modified: k8smetav1.Time{Time: utc}},
+	)
+	require.Nil(err)
+	require.Empty(delta.Differences)
+
+	later := k8smetav1.NewTime(now.Add(time.Second))
+	delta, err = compare.Diff(
+		metaSpec{Created: &k8smetav1.Time{Time: now}, Modified: k8smetav1.Time{Time: now}},
+		metaSpec{Created: &later, Modified: later},
+	)
+	require.Nil(err)
+	require.Len(delta.Differences, 2)
+	require.Equal("Created", delta.Differences[0].Path.String())
+	require.Equal("Modified", delta.Differences[1].Path.String())
+}
+
+func TestDiff_Quantity(t *testing.T) {
+	require := require.New(t)
+
+	type quantitySpec struct {
+		Storage *resource.Quantity
+		Memory  resource.Quantity
+	}
+	a := quantitySpec{
+		Storage: resource.NewQuantity(1<<30, resource.BinarySI),
+		Memory:  resource.MustParse("1Gi"),
+	}
+	b := quantitySpec{
+		Storage: resource.NewQuantity(2<<30, resource.BinarySI),
+		Memory:  resource.MustParse("1024Mi"),
+	}
+	delta, err := compare.Diff(a, b)
+	require.Nil(err)
+	require.Len(delta.Differences, 1)
+	require.Equal("Storage", delta.Differences[0].Path.String())
+}
+
+func TestDiff_TypeMismatch(t *testing.T) {
+	require := require.New(t)
+
+	_, err := compare.Diff(testSpec{}, testConfig{})
+	require.NotNil(err)
+
+	delta, err := compare.Diff(nil, &testSpec{})
+	require.Nil(err)
+	require.Len(delta.Differences, 1)
+
+	delta, err = compare.Diff((*testSpec)(nil), nil)
+	require.Nil(err)
+	require.Empty(delta.Differences)
+}
+
+// TestDiff_HelperSemantics verifies that Diff agrees with the
+// type-specific helper functions generated code uses today
+func TestDiff_HelperSemantics(t *testing.T) {
+	require := require.New(t)
+
+	type subject struct {
+		SlicePs []*string
+		Map     map[string]*string
+	}
+
+	slices := [][]*string{
+		nil,
+		{},
+		{strP("a")},
+		{strP("b")},
+		{strP("a"), strP("b")},
+		{strP("b"), strP("a")},
+		{strP("a"), strP("a"), strP("b")},
+		{strP("a"), strP("b"), strP("b")},
+	}
+	for _, sa := range slices {
+		for _, sb := range slices {
+			delta, err := compare.Diff(subject{SlicePs: sa}, subject{SlicePs: sb})
+			require.Nil(err)
+			expect := compare.HasNilDifference(sa, sb) ||
+				!compare.SliceStringPEqual(sa, sb)
+			require.Equal(expect, delta.DifferentAt("SlicePs"), "%v %v", sa, sb)
+		}
+	}
+
+	maps := []map[string]*string{
+		nil,
+		{},
+		{"a": strP("a")},
+		{"a": strP("b")},
+		{"b": strP("a")},
+		{"a": strP("a"), "b": strP("b")},
+	}
+	for _, ma := range maps {
+		for _, mb := range maps {
+			delta, err := compare.Diff(subject{Map: ma}, subject{Map: mb})
+			require.Nil(err)
+			expect := compare.HasNilDifference(ma, mb) ||
+				!compare.MapStringStringPEqual(ma, mb)
+			require.Equal(expect, delta.DifferentAt("Map"), "%v %v", ma, mb)
+		}
+	}
+}
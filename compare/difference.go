@@ -13,6 +13,40 @@
 
 package compare
 
+import "reflect"
+
+// DifferenceKind describes how a value changed between the two resources
+// under comparison
+type DifferenceKind string
+
+const (
+	// KindAdded is the kind of a Difference whose A value is nil and whose B
+	// value is not
+	KindAdded DifferenceKind = "Added"
+	// KindRemoved is the kind of a Difference whose B value is nil and whose A
+	// value is not
+	KindRemoved DifferenceKind = "Removed"
+	// KindModified is the kind of a Difference whose A and B values are of
+	// the same type
+	KindModified DifferenceKind = "Modified"
+	// KindTypeChanged is the kind of a Difference whose A and B values are of
+	// different types, e.g. in an untyped map[string]interface{} document
+	KindTypeChanged DifferenceKind = "TypeChanged"
+)
+
+// kindOf returns the kind of a Difference with the supplied values
+func kindOf(a, b interface{}) DifferenceKind {
+	switch {
+	case isNilValue(a) && !isNilValue(b):
+		return KindAdded
+	case !isNilValue(a) && isNilValue(b):
+		return KindRemoved
+	case a != nil && b != nil && reflect.TypeOf(a) != reflect.TypeOf(b):
+		return KindTypeChanged
+	}
+	return KindModified
+}
+
 // Difference contains the difference in values for a specified field path into
 // two compared resources.
 type Difference struct {
@@ -23,4 +57,30 @@ type Difference struct {
 	A interface{}
 	// B is the value of the first resource under comparison at the Path
 	B interface{}
+	// Kind describes how the value at the Path changed. It is populated by
+	// Delta.Add and by Diff.
+	Kind DifferenceKind
+	// Sensitive is true if A and B must be redacted whenever the Difference
+	// is rendered or serialized. See SensitivePaths.
+	Sensitive bool
+	// Embedded is true if the Difference was recorded within a JSON or YAML
+	// document held by a string field, in which case A and B are parsed
+	// values rather than values of the field. See EmbeddedDocuments.
+	Embedded bool
+	// jsonPath contains the JSON object member names and array indexes
+	// corresponding to each part of the Path. It is only populated for
+	// Differences recorded by Diff.
+	jsonPath []string
+	// redactedValues is true if the Difference was decoded from JSON in which
+	// its non-nil values were redacted, so A and B are not the real values
+	redactedValues bool
+}
+
+// kind returns the Kind of the Difference, deriving it from the values if the
+// Difference was not created by Delta.Add or Diff
+func (d *Difference) kind() DifferenceKind {
+	if d.Kind != "" {
+		return d.Kind
+	}
+	return kindOf(d.A, d.B)
 }
@@ -0,0 +1,105 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/pkg/compare"
+)
+
+func TestDifference_Kind(t *testing.T) {
+	require := require.New(t)
+
+	testCases := []struct {
+		a, b   interface{}
+		expect compare.DifferenceKind
+	}{
+		{nil, strP("a"), compare.KindAdded},
+		{(*string)(nil), strP("a"), compare.KindAdded},
+		{[]string(nil), []string{}, compare.KindAdded},
+		{strP("a"), nil, compare.KindRemoved},
+		{map[string]string{}, map[string]string(nil), compare.KindRemoved},
+		{strP("a"), strP("b"), compare.KindModified},
+		{"", "a", compare.KindModified},
+		{nil, nil, compare.KindModified},
+		{"10", 10, compare.KindTypeChanged},
+		{map[string]interface{}{}, []interface{}{}, compare.KindTypeChanged},
+	}
+	for _, tc := range testCases {
+		delta := compare.NewDelta()
+		delta.Add("Spec.Field", tc.a, tc.b)
+		require.Equal(
+			tc.expect, delta.Differences[0].Kind,
+			fmt.Sprintf("for A %#v and B %#v", tc.a, tc.b),
+		)
+	}
+}
+
+func TestDiff_Kind(t *testing.T) {
+	require := require.New(t)
+
+	a := testResource{Spec: testSpec{
+		AllocatedStorage: int64P(20),
+		Config:           &testConfig{Name: strP("cfg")},
+		Labels:           map[string]*string{"env": strP("dev"), "team": strP("a")},
+		Extra:            map[string]interface{}{"port": "80", "zone": "a"},
+	}}
+	b := testResource{Spec: testSpec{
+		AllocatedStorage: int64P(40),
+		Engine:           strP("postgres"),
+		Labels:           map[string]*string{"env": strP("dev"), "owner": strP("b")},
+		Extra:            map[string]interface{}{"port": 80.0, "zone": "b"},
+	}}
+	delta, err := compare.Diff(a, b)
+	require.NoError(err)
+
+	kinds := map[string]compare.DifferenceKind{}
+	for _, diff := range delta.Differences {
+		kinds[diff.Path.String()] = diff.Kind
+	}
+	require.Equal(map[string]compare.DifferenceKind{
+		"Spec.AllocatedStorage": compare.KindModified,
+		"Spec.Engine":           compare.KindAdded,
+		"Spec.Config":           compare.KindRemoved,
+		"Spec.Labels.owner":     compare.KindAdded,
+		"Spec.Labels.team":      compare.KindRemoved,
+		"Spec.Extra.port":       compare.KindTypeChanged,
+		"Spec.Extra.zone":       compare.KindModified,
+	}, kinds)
+
+	paths := func(d *compare.Delta) []string {
+		out := []string{}
+		for _, p := range d.Paths() {
+			out = append(out, p.String())
+		}
+		return out
+	}
+	require.Equal([]string{"Spec.Engine", "Spec.Labels.owner"}, paths(delta.Added()))
+	require.Equal([]string{"Spec.Config", "Spec.Labels.team"}, paths(delta.Removed()))
+	require.Equal(
+		[]string{"Spec.AllocatedStorage", "Spec.Extra.port", "Spec.Extra.zone"},
+		paths(delta.Modified()),
+	)
+
+	// The kind of Differences created directly is derived from their values
+	delta = &compare.Delta{Differences: []*compare.Difference{
+		{Path: compare.NewPath("Spec.Name"), B: strP("a")},
+	}}
+	require.Len(delta.Added().Differences, 1)
+	require.Empty(delta.Modified().Differences)
+}
@@ -0,0 +1,332 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// JSONStringEqual returns true if the supplied strings contain equivalent JSON
+// documents, regardless of whitespace, object member order or URL-encoding of
+// either string. AWS APIs commonly return JSON documents stored as strings,
+// e.g. resource policies, reformatted and URL-encoded, so comparing them as
+// strings reports differences that do not exist.
+//
+// If the documents are not equivalent, the returned Delta contains the
+// differences between them, as returned by Diff, with Paths made up of object
+// member names and array element selectors, e.g. "Statement[0].Effect". Any
+// supplied Options are passed to Diff, so WithPathPrefix may be used to record
+// the differences under the Path of the field holding the document, e.g.
+// WithPathPrefix("Spec.Policy"). Arrays are compared in order.
+//
+// An empty or whitespace-only string is treated as a missing document, which
+// is equal only to another missing document. An error is returned if either
+// string is not a valid JSON document.
+func JSONStringEqual(a, b string, opts ...Option) (bool, *Delta, error) {
+	docA, err := parseJSONString(a)
+	if err != nil {
+		return false, nil, err
+	}
+	docB, err := parseJSONString(b)
+	if err != nil {
+		return false, nil, err
+	}
+	return documentsEqual(docA, docB, opts)
+}
+
+// YAMLStringEqual returns true if the supplied strings contain equivalent YAML
+// documents, regardless of formatting, comments, mapping key order or style,
+// e.g. flow or block collections and quoted or plain scalars. Since JSON is a
+// subset of YAML, either string may also contain a JSON document, so
+// YAMLStringEqual is suitable for fields holding documents in either format,
+// e.g. CloudFormation templates, Step Functions state machine definitions or
+// EventBridge event patterns.
+//
+// Documents are compared as JSON values would be: integers and floats are
+// numbers, so 1 and 1.0 are equal, timestamps are strings and mapping keys
+// are converted to strings. Values with application-specific tags, e.g.
+// CloudFormation's "!Ref Bucket", are compared as a mapping from the tag to
+// the value, e.g. {"!Ref": "Bucket"}, so that a tag is never ignored.
+//
+// If the documents are not equivalent, the returned Delta contains the
+// differences between them, as for JSONStringEqual. Lists are compared in
+// order unless the UnorderedLists Option is supplied. Any supplied Options
+// are passed to Diff, so WithPathPrefix may be used to record the
+// differences under the Path of the field holding the document, e.g.
+// WithPathPrefix("Spec.Definition") records "Spec.Definition.States.Foo.Type".
+//
+// An empty string, or one containing only whitespace and comments, is treated
+// as a missing document. An error is returned if either string is not a valid
+// YAML document or contains more than one document.
+func YAMLStringEqual(a, b string, opts ...Option) (bool, *Delta, error) {
+	docA, err := parseYAMLString(a)
+	if err != nil {
+		return false, nil, err
+	}
+	docB, err := parseYAMLString(b)
+	if err != nil {
+		return false, nil, err
+	}
+	return documentsEqual(docA, docB, opts)
+}
+
+// isDocument returns true if the string at the supplied path holds a JSON or
+// YAML document
+func (d *differ) isDocument(path []pathPart) bool {
+	if len(d.opts.documents) == 0 {
+		return false
+	}
+	parts := partNames(path)
+	for _, pattern := range d.opts.documents {
+		if pattern.matches(parts) {
+			return true
+		}
+	}
+	return false
+}
+
+// diffDocuments records the differences between the documents held by the
+// supplied strings, or pointers to strings, at the supplied path. It returns
+// false, recording nothing, if either value is not a string holding a valid
+// document, so that the values are compared as is.
+func (d *differ) diffDocuments(path []pathPart, a, b reflect.Value) bool {
+	sa, ok := documentString(a)
+	if !ok {
+		return false
+	}
+	sb, ok := documentString(b)
+	if !ok {
+		return false
+	}
+	docA, err := parseYAMLString(sa)
+	if err != nil {
+		return false
+	}
+	docB, err := parseYAMLString(sb)
+	if err != nil {
+		return false
+	}
+	// The parsed documents are walked at the same path as the strings holding
+	// them, so they must not be treated as documents again
+	opts := *d.opts
+	opts.documents = nil
+	equalOpts := *d.equalOpts
+	equalOpts.documents = nil
+	sub := &differ{
+		opts:      &opts,
+		delta:     d.delta,
+		equalOpts: &equalOpts,
+		embedded:  true,
+		visiting:  d.visiting,
+	}
+	sub.diff(path, reflect.ValueOf(&docA).Elem(), reflect.ValueOf(&docB).Elem())
+	return true
+}
+
+// hasEmbedded returns the first Difference in the delta recorded within an
+// embedded document, or nil
+func (d *Delta) hasEmbedded() *Difference {
+	for _, diff := range d.Differences {
+		if diff.Embedded {
+			return diff
+		}
+	}
+	return nil
+}
+
+// documentString returns the string held by the supplied string value or
+// non-nil pointer to a string value
+func documentString(v reflect.Value) (string, bool) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.String {
+		return "", false
+	}
+	return v.String(), true
+}
+
+// documentsEqual returns true if the supplied parsed documents are equal, and
+// a Delta containing the differences between them
+func documentsEqual(a, b any, opts []Option) (bool, *Delta, error) {
+	// The documents may hold values of different types, e.g. an object and an
+	// array, which Diff refuses to compare directly. Pointers to interfaces
+	// are walked to the values they hold, which are then compared as
+	// interface values.
+	delta, err := Diff(&a, &b, opts...)
+	if err != nil {
+		return false, nil, err
+	}
+	return len(delta.Differences) == 0, delta, nil
+}
+
+// parseJSONString returns the JSON document contained in the supplied string,
+// decoding it first if it is URL-encoded, or nil if the string is empty
+func parseJSONString(s string) (any, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	var doc any
+	err := json.Unmarshal([]byte(s), &doc)
+	if err == nil {
+		return doc, nil
+	}
+	if strings.Contains(s, "%") {
+		if unescaped, uerr := url.PathUnescape(s); uerr == nil {
+			if uerr = json.Unmarshal([]byte(unescaped), &doc); uerr == nil {
+				return doc, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("invalid JSON document: %v", err)
+}
+
+// parseYAMLString returns the YAML document contained in the supplied string,
+// converted to the values that encoding/json would decode from the equivalent
+// JSON document, or nil if the string contains no document
+func parseYAMLString(s string) (any, error) {
+	dec := yaml.NewDecoder(strings.NewReader(s))
+	var node yaml.Node
+	if err := dec.Decode(&node); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("invalid YAML document: %v", err)
+	}
+	var next yaml.Node
+	if err := dec.Decode(&next); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid YAML document: expected a single document")
+	}
+	conv := &yamlConverter{ancestors: map[*yaml.Node]bool{}}
+	return conv.value(&node)
+}
+
+// maxYAMLAliasNodes is the maximum number of nodes that may be expanded from
+// aliases in a YAML document, which protects against documents whose aliases
+// expand exponentially, e.g. "billion laughs" documents
+const maxYAMLAliasNodes = 10000
+
+// yamlConverter converts YAML nodes to values, guarding against recursive and
+// exponentially expanding aliases
+type yamlConverter struct {
+	// ancestors contains the nodes being converted, from the document node to
+	// the current node
+	ancestors map[*yaml.Node]bool
+	// aliasDepth is the number of aliases being followed
+	aliasDepth int
+	// aliasNodes is the number of nodes converted while following aliases
+	aliasNodes int
+}
+
+// value returns the value of the supplied YAML node as a
+// map[string]interface{}, []interface{}, string, float64, bool or nil
+func (c *yamlConverter) value(node *yaml.Node) (any, error) {
+	if c.ancestors[node] {
+		return nil, fmt.Errorf(
+			"invalid YAML document: alias at line %d refers to an enclosing node",
+			node.Line,
+		)
+	}
+	if c.aliasDepth > 0 {
+		c.aliasNodes++
+		if c.aliasNodes > maxYAMLAliasNodes {
+			return nil, fmt.Errorf(
+				"invalid YAML document: aliases expand to more than %d nodes",
+				maxYAMLAliasNodes,
+			)
+		}
+	}
+	c.ancestors[node] = true
+	defer delete(c.ancestors, node)
+
+	var value any
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil, nil
+		}
+		return c.value(node.Content[0])
+	case yaml.AliasNode:
+		c.aliasDepth++
+		defer func() { c.aliasDepth-- }()
+		return c.value(node.Alias)
+	case yaml.MappingNode:
+		m := map[string]interface{}{}
+		for x := 0; x+1 < len(node.Content); x += 2 {
+			key, err := c.value(node.Content[x])
+			if err != nil {
+				return nil, err
+			}
+			elem, err := c.value(node.Content[x+1])
+			if err != nil {
+				return nil, err
+			}
+			m[fmt.Sprint(key)] = elem
+		}
+		value = m
+	case yaml.SequenceNode:
+		elems := make([]interface{}, 0, len(node.Content))
+		for _, child := range node.Content {
+			elem, err := c.value(child)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, elem)
+		}
+		value = elems
+	case yaml.ScalarNode:
+		// Application-specific tags are resolved separately below, so the
+		// scalar is decoded as it would be without one
+		scalar := *node
+		if isYAMLLocalTag(node.Tag) {
+			scalar.Tag = ""
+		}
+		switch scalar.ShortTag() {
+		case "!!timestamp":
+			value = node.Value
+		case "!!int", "!!float":
+			var f float64
+			if err := scalar.Decode(&f); err != nil {
+				return nil, fmt.Errorf("invalid YAML document: %v", err)
+			}
+			value = f
+		default:
+			if err := scalar.Decode(&value); err != nil {
+				return nil, fmt.Errorf("invalid YAML document: %v", err)
+			}
+		}
+	}
+	if isYAMLLocalTag(node.Tag) {
+		return map[string]interface{}{node.Tag: value}, nil
+	}
+	return value, nil
+}
+
+// isYAMLLocalTag returns true if the supplied YAML tag is application-specific,
+// e.g. "!Ref", rather than one of the standard tags, e.g. "!!str"
+func isYAMLLocalTag(tag string) bool {
+	return strings.HasPrefix(tag, "!") && !strings.HasPrefix(tag, "!!")
+}
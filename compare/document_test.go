@@ -0,0 +1,196 @@
+package compare_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/pkg/compare"
+)
+
+func TestJSONStringEqual(t *testing.T) {
+	require := require.New(t)
+
+	testCases := []struct {
+		a, b   string
+		expect bool
+		paths  []string
+	}{
+		{`{"a":1,"b":[1,2]}`, `{ "b": [1, 2],
+			"a": 1 }`, true, nil},
+		{`{"a":1}`, `%7B%22a%22%3A1%7D`, true, nil},
+		{`{"a":"x y"}`, `%7B%22a%22%3A%22x%20y%22%7D`, true, nil},
+		{`{"a":1.0}`, `{"a":1}`, true, nil},
+		{"", " ", true, nil},
+		{`{"a":1}`, `{"a":2}`, false, []string{"a"}},
+		{`{"a":{"b":"x"}}`, `{"a":{"b":"y","c":"z"}}`, false, []string{"a.b", "a.c"}},
+		{`{"a":[1,2]}`, `{"a":[2,1]}`, false, []string{"a[0]", "a[1]"}},
+		{`{"a":[1,2]}`, `{"a":[1]}`, false, []string{"a"}},
+		{`{"a":"1"}`, `{"a":1}`, false, []string{"a"}},
+		{`{"a":1}`, `[1]`, false, []string{""}},
+		{`{"a":1}`, ``, false, []string{""}},
+	}
+	for _, tc := range testCases {
+		msg := fmt.Sprintf("for %s and %s", tc.a, tc.b)
+		equal, delta, err := compare.JSONStringEqual(tc.a, tc.b)
+		require.NoError(err, msg)
+		require.Equal(tc.expect, equal, msg)
+		paths := []string{}
+		for _, p := range delta.Paths() {
+			paths = append(paths, p.String())
+		}
+		if tc.paths == nil {
+			tc.paths = []string{}
+		}
+		require.Equal(tc.paths, paths, msg)
+	}
+}
+
+func TestJSONStringEqual_PathPrefix(t *testing.T) {
+	require := require.New(t)
+
+	equal, delta, err := compare.JSONStringEqual(
+		`{"a":{"b":"x"}}`, `{"a":{"b":"y"}}`,
+		compare.WithPathPrefix("Spec.Document"),
+	)
+	require.NoError(err)
+	require.False(equal)
+	require.Len(delta.Differences, 1)
+	require.Equal("Spec.Document.a.b", delta.Differences[0].Path.String())
+	require.Equal("x", delta.Differences[0].A)
+	require.Equal("y", delta.Differences[0].B)
+	require.True(delta.DifferentAt("Spec.Document"))
+}
+
+func TestJSONStringEqual_Invalid(t *testing.T) {
+	require := require.New(t)
+
+	_, _, err := compare.JSONStringEqual(`{"a":`, `{}`)
+	require.Error(err)
+	_, _, err = compare.JSONStringEqual(`{}`, `%7B%22a`)
+	require.Error(err)
+}
+
+func TestYAMLStringEqual(t *testing.T) {
+	require := require.New(t)
+
+	testCases := []struct {
+		a, b   string
+		expect bool
+		paths  []string
+	}{
+		{"a: 1\nb: [x, y]\n", "# comment\nb:\n  - x\n  - \"y\"\na: 1.0\n", true, nil},
+		{`{"a": {"b": true}}`, "a:\n  b: true\n", true, nil},
+		{"a: &x {b: 1}\nc: *x\n", "a: {b: 1}\nc: {b: 1}\n", true, nil},
+		{"a: 2001-12-14\n", "a: '2001-12-14'\n", true, nil},
+		{"1: x\n", `{"1": "x"}`, true, nil},
+		{"", "# nothing\n", true, nil},
+		{"a: !Ref Bucket\n", "a: !Ref Bucket\n", true, nil},
+		{"a: !Ref Bucket\n", "a: Bucket\n", false, []string{"a"}},
+		{"a: !GetAtt [Role, Arn]\n", "a: !GetAtt [Role, Name]\n", false, []string{"a.!GetAtt[1]"}},
+		{"a: 'true'\n", "a: true\n", false, []string{"a"}},
+		{"a: [x, y]\n", "a: [y, x]\n", false, []string{"a[0]", "a[1]"}},
+		{"a: [{b: 1}, {b: 2}]\n", "a: [{b: 1}, {b: 3}]\n", false, []string{"a[1].b"}},
+	}
+	for _, tc := range testCases {
+		msg := fmt.Sprintf("for %q and %q", tc.a, tc.b)
+		equal, delta, err := compare.YAMLStringEqual(tc.a, tc.b)
+		require.NoError(err, msg)
+		require.Equal(tc.expect, equal, msg)
+		paths := []string{}
+		for _, p := range delta.Paths() {
+			paths = append(paths, p.String())
+		}
+		if tc.paths == nil {
+			tc.paths = []string{}
+		}
+		require.Equal(tc.paths, paths, msg)
+	}
+}
+
+func TestYAMLStringEqual_UnorderedLists(t *testing.T) {
+	require := require.New(t)
+
+	a := "detail-type: [A, B]\nsource: [{prefix: x}, {prefix: y}]\n"
+	b := "source: [{prefix: y}, {prefix: x}]\ndetail-type: [B, A]\n"
+
+	equal, delta, err := compare.YAMLStringEqual(a, b)
+	require.NoError(err)
+	require.False(equal)
+	require.Len(delta.Differences, 4)
+
+	equal, _, err = compare.YAMLStringEqual(a, b, compare.UnorderedLists())
+	require.NoError(err)
+	require.True(equal)
+
+	equal, delta, err = compare.YAMLStringEqual(
+		a, b,
+		compare.WithPathPrefix("Spec.EventPattern"),
+		compare.UnorderedLists("Spec.EventPattern.source"),
+	)
+	require.NoError(err)
+	require.False(equal)
+	require.Len(delta.Differences, 2)
+	require.True(delta.DifferentAt("Spec.EventPattern.detail-type"))
+	require.False(delta.DifferentAt("Spec.EventPattern.source"))
+}
+
+func TestYAMLStringEqual_PathPrefix(t *testing.T) {
+	require := require.New(t)
+
+	equal, delta, err := compare.YAMLStringEqual(
+		`{"StartAt": "Foo", "States": {"Foo": {"Type": "Pass", "End": true}}}`,
+		"StartAt: Foo\nStates:\n  Foo:\n    Type: Task\n    End: true\n",
+		compare.WithPathPrefix("Spec.Definition"),
+	)
+	require.NoError(err)
+	require.False(equal)
+	require.Len(delta.Differences, 1)
+	require.Equal("Spec.Definition.States.Foo.Type", delta.Differences[0].Path.String())
+	require.Equal("Pass", delta.Differences[0].A)
+	require.Equal("Task", delta.Differences[0].B)
+}
+
+func TestYAMLStringEqual_Invalid(t *testing.T) {
+	require := require.New(t)
+
+	_, _, err := compare.YAMLStringEqual("a: [", "a: 1")
+	require.Error(err)
+	_, _, err = compare.YAMLStringEqual("a: 1", "a: 1\n---\nb: 2\n")
+	require.Error(err)
+
+	// Aliases referring to an enclosing node
+	_, _, err = compare.YAMLStringEqual("&a [*a]", "x: 1")
+	require.Error(err)
+	_, _, err = compare.YAMLStringEqual("x: 1", "a: &b {c: [*b]}")
+	require.Error(err)
+
+	// Aliases expanding exponentially
+	var laughs strings.Builder
+	laughs.WriteString("l0: &l0 [lol, lol, lol, lol, lol, lol, lol, lol, lol]\n")
+	for x := 1; x <= 9; x++ {
+		fmt.Fprintf(&laughs, "l%d: &l%d [", x, x)
+		for y := 0; y < 9; y++ {
+			if y > 0 {
+				laughs.WriteString(", ")
+			}
+			fmt.Fprintf(&laughs, "*l%d", x-1)
+		}
+		laughs.WriteString("]\n")
+	}
+	start := time.Now()
+	_, _, err = compare.YAMLStringEqual(laughs.String(), "x: 1")
+	require.Error(err)
+	require.Less(time.Since(start), 5*time.Second)
+
+	// Aliases within the limit are expanded
+	equal, _, err := compare.YAMLStringEqual(
+		"a: &x {b: [1, 2]}\nc: [*x, *x]\n",
+		"a: {b: [1, 2]}\nc: [{b: [1, 2]}, {b: [1, 2]}]\n",
+	)
+	require.NoError(err)
+	require.True(equal)
+}
@@ -0,0 +1,144 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// DeltaJSONVersion is the version of the JSON encoding of a Delta produced by
+// json.Marshal. Version 1 encodes a Delta as:
+//
+//	{
+//	  "Version": 1,
+//	  "Differences": [
+//	    {
+//	      "Path": {"Parts": ["Spec", "AllocatedStorage"]},
+//	      "Kind": "Modified",
+//	      "A": 20,
+//	      "B": 50
+//	    },
+//	    {
+//	      "Path": {"Parts": ["Spec", "MasterUserPassword"]},
+//	      "Kind": "Added",
+//	      "A": null,
+//	      "B": "<redacted>",
+//	      "Sensitive": true
+//	    }
+//	  ]
+//	}
+//
+// A and B are the JSON encodings of the compared values, redacted if the
+// Difference is sensitive. Sensitive is omitted unless it is true, as is
+// Embedded, which marks Differences within embedded documents.
+//
+// When decoding, a Path may also be given as a dotted-notation string, e.g.
+// "Spec.AllocatedStorage", a missing Kind is derived from A and B, and a
+// missing Version is treated as version 1, which matches the encoding of a
+// Delta produced before the encoding was versioned. Decoded A and B values
+// have the types produced by encoding/json for an interface{}, e.g. float64
+// for numbers. The redacted values of a decoded sensitive Difference cannot
+// be applied using Delta.ApplyTo.
+const DeltaJSONVersion = 1
+
+// deltaJSON is the JSON encoding of a Delta
+type deltaJSON struct {
+	Version     int
+	Differences []*Difference
+}
+
+// differenceJSON is the JSON encoding of a Difference
+type differenceJSON struct {
+	Path      Path
+	Kind      DifferenceKind `json:",omitempty"`
+	A         interface{}
+	B         interface{}
+	Sensitive bool `json:",omitempty"`
+	Embedded  bool `json:",omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler. See DeltaJSONVersion.
+func (d Delta) MarshalJSON() ([]byte, error) {
+	diffs := d.Differences
+	if diffs == nil {
+		diffs = []*Difference{}
+	}
+	return json.Marshal(deltaJSON{DeltaJSONVersion, diffs})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. See DeltaJSONVersion.
+func (d *Delta) UnmarshalJSON(data []byte) error {
+	var decoded deltaJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	if decoded.Version > DeltaJSONVersion || decoded.Version < 0 {
+		return fmt.Errorf(
+			"unsupported delta encoding version %d, expected at most %d",
+			decoded.Version, DeltaJSONVersion,
+		)
+	}
+	d.Differences = decoded.Differences
+	if d.Differences == nil {
+		d.Differences = []*Difference{}
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, redacting the values of a sensitive
+// Difference. It has a value receiver so that sensitive values are redacted
+// whether a Difference or a pointer to one is marshalled. See
+// DeltaJSONVersion.
+func (d Difference) MarshalJSON() ([]byte, error) {
+	a, b := d.redacted(nil)
+	return json.Marshal(differenceJSON{
+		Path:      d.Path,
+		Kind:      d.kind(),
+		A:         a,
+		B:         b,
+		Sensitive: d.Sensitive,
+		Embedded:  d.Embedded,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. See DeltaJSONVersion.
+func (d *Difference) UnmarshalJSON(data []byte) error {
+	var decoded differenceJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	if decoded.Path.parts == nil {
+		return fmt.Errorf("difference has no Path")
+	}
+	kind := decoded.Kind
+	switch kind {
+	case "":
+		kind = kindOf(decoded.A, decoded.B)
+	case KindAdded, KindRemoved, KindModified, KindTypeChanged:
+	default:
+		return fmt.Errorf("unknown difference kind %q", kind)
+	}
+	*d = Difference{
+		Path:      decoded.Path,
+		Kind:      kind,
+		A:         decoded.A,
+		B:         decoded.B,
+		Sensitive: decoded.Sensitive,
+		Embedded:  decoded.Embedded,
+		// Sensitive values are always redacted by MarshalJSON
+		redactedValues: decoded.Sensitive,
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare_test
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/pkg/compare"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files in testdata")
+
+// requireGolden compares the supplied JSON to the contents of the named golden
+// file in testdata, rewriting the file instead if -update is supplied
+func requireGolden(t *testing.T, name string, got []byte) {
+	path := filepath.Join("testdata", name)
+	if *updateGolden {
+		require.NoError(t, os.WriteFile(path, append(got, '\n'), 0o644))
+	}
+	expect, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, string(expect), string(got)+"\n")
+}
+
+func goldenDelta(t *testing.T) *compare.Delta {
+	a := testResource{Spec: testSpec{
+		AllocatedStorage: int64P(20),
+		Engine:           strP("postgres"),
+		Config:           &testConfig{Name: strP("hunter2")},
+		Rules:            []*testRule{{Port: int64P(80)}},
+		Labels:           map[string]*string{"team": strP("a")},
+		Extra:            map[string]interface{}{"port": "80"},
+	}}
+	b := testResource{Spec: testSpec{
+		AllocatedStorage: int64P(50),
+		Config:           &testConfig{Name: strP("correct-horse")},
+		Rules:            []*testRule{{Port: int64P(443), Protocol: strP("tcp")}},
+		Labels:           map[string]*string{"team": strP("a"), "env": strP("dev")},
+		Extra:            map[string]interface{}{"port": 80},
+	}}
+	delta, err := compare.Diff(a, b, compare.SensitivePaths("Spec.Config.Name"))
+	require.NoError(t, err)
+	return delta
+}
+
+func TestDelta_JSON_Golden(t *testing.T) {
+	require := require.New(t)
+
+	encoded, err := json.MarshalIndent(goldenDelta(t), "", "  ")
+	require.NoError(err)
+	requireGolden(t, "delta_v1.json", encoded)
+
+	encoded, err = json.MarshalIndent(compare.NewDelta(), "", "  ")
+	require.NoError(err)
+	requireGolden(t, "delta_v1_empty.json", encoded)
+}
+
+func TestDelta_JSON_RoundTrip(t *testing.T) {
+	require := require.New(t)
+
+	golden, err := os.ReadFile(filepath.Join("testdata", "delta_v1.json"))
+	require.NoError(err)
+
+	var delta compare.Delta
+	require.NoError(json.Unmarshal(golden, &delta))
+	require.Len(delta.Differences, 7)
+
+	original := goldenDelta(t)
+	for x, diff := range delta.Differences {
+		require.Equal(original.Differences[x].Path, diff.Path)
+		require.Equal(original.Differences[x].Kind, diff.Kind)
+		require.Equal(original.Differences[x].Sensitive, diff.Sensitive)
+	}
+	require.True(delta.DifferentAt("Spec.Rules[0].Port"))
+	require.Equal(50.0, delta.Differences[0].B)
+	require.Equal(compare.RedactedValue, delta.Differences[2].A)
+
+	encoded, err := json.MarshalIndent(&delta, "", "  ")
+	require.NoError(err)
+	require.Equal(string(golden), string(encoded)+"\n")
+}
+
+func TestDelta_JSON_Decoding(t *testing.T) {
+	require := require.New(t)
+
+	// Deltas encoded before the encoding was versioned, with dotted Paths and
+	// without Kinds
+	var delta compare.Delta
+	require.NoError(json.Unmarshal([]byte(`{
+		"Differences": [
+			{"Path": {"Parts": ["Spec", "Name"]}, "A": "a", "B": "b"},
+			{"Path": "Spec.Rules[1].Port", "A": null, "B": 443},
+			{"Path": "Spec.Tags[Key=env]", "A": {"Key": "env"}, "B": null}
+		]
+	}`), &delta))
+	require.Len(delta.Differences, 3)
+	require.Equal("Spec.Name", delta.Differences[0].Path.String())
+	require.Equal(compare.KindModified, delta.Differences[0].Kind)
+	require.Equal(compare.NewPath("Spec.Rules[1].Port"), delta.Differences[1].Path)
+	require.Equal(compare.KindAdded, delta.Differences[1].Kind)
+	require.Equal(compare.KindRemoved, delta.Differences[2].Kind)
+	require.True(delta.DifferentAt("Spec.Tags[Key=env]"))
+
+	require.NoError(json.Unmarshal([]byte(`{"Version": 1}`), &delta))
+	require.NotNil(delta.Differences)
+	require.Empty(delta.Differences)
+
+	for _, invalid := range []string{
+		`{"Version": 2, "Differences": []}`,
+		`{"Version": 1, "Differences": [{"A": 1, "B": 2}]}`,
+		`{"Version": 1, "Differences": [{"Path": "Spec", "Kind": "Renamed"}]}`,
+		`{"Version": 1, "Differences": [{"Path": 42}]}`,
+	} {
+		require.Error(json.Unmarshal([]byte(invalid), &delta), invalid)
+	}
+}
+
+func TestPath_JSON(t *testing.T) {
+	require := require.New(t)
+
+	p := compare.NewPath("Spec.Rules[0].Port")
+	encoded, err := json.Marshal(p)
+	require.NoError(err)
+	require.Equal(`{"Parts":["Spec","Rules","[0]","Port"]}`, string(encoded))
+
+	var decoded compare.Path
+	require.NoError(json.Unmarshal(encoded, &decoded))
+	require.Equal(p, decoded)
+
+	require.NoError(json.Unmarshal([]byte(`"Spec.Rules[0].Port"`), &decoded))
+	require.Equal(p, decoded)
+
+	require.Error(json.Unmarshal([]byte(`["Spec"]`), &decoded))
+}
@@ -0,0 +1,278 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultMaxValueLength is the maximum number of characters of a value
+	// rendered by Delta.String
+	DefaultMaxValueLength = 64
+	// truncationMarker is appended to truncated values
+	truncationMarker = "..."
+	// nilValue is the rendering of a nil value
+	nilValue = "<nil>"
+	// cycleValue is the rendering of a value reached again through a cycle
+	cycleValue = "<cycle>"
+	// rootPath is the rendering of the Path of a difference at the root of the
+	// compared values
+	rootPath = "<root>"
+)
+
+// FormatOptions configures the rendering of a Delta by Delta.Format
+type FormatOptions struct {
+	// MaxValueLength is the maximum number of characters of each rendered
+	// value. Longer values are truncated and end with "...". Zero means
+	// DefaultMaxValueLength and a negative value means no limit.
+	MaxValueLength int
+	// MultiLine renders each difference over multiple lines, in the style of
+	// a unified diff, instead of on a single line. For example:
+	//
+	//	Spec.AllocatedStorage
+	//	- 20
+	//	+ 50
+	//
+	// A nil value is omitted, so a field that was set renders only a "+"
+	// line.
+	MultiLine bool
+	// SensitiveValueHashKey, if not empty, renders the values of sensitive
+	// Differences as an HMAC-SHA256 of the value keyed with it, e.g.
+	// "hmac-sha256:2c26b46b68ffc68f", instead of as RedactedValue, so that a
+	// change to a sensitive value can be noticed without revealing it. The
+	// key must be kept secret, e.g. generated randomly when the controller
+	// starts, since anyone holding it can test guesses of a value against
+	// its hash. See SensitivePaths.
+	SensitiveValueHashKey []byte
+}
+
+// String returns a single-line rendering of the delta suitable for log lines
+// and Kubernetes events, e.g.:
+//
+//	Spec.AllocatedStorage: 20 -> 50; Spec.Engine: <nil> -> "postgres"
+//
+// See Format.
+func (d *Delta) String() string {
+	return d.Format(FormatOptions{})
+}
+
+// Format returns a rendering of the delta using the supplied options.
+// Differences are sorted by Path. Pointers are dereferenced, strings are
+// quoted and structs, lists and maps are rendered compactly, e.g.
+// {Name:"cfg" Ports:[80 443]}, omitting nil and zero struct fields. Map keys
+// are sorted. The values of sensitive Differences are redacted.
+func (d *Delta) Format(opts FormatOptions) string {
+	diffs := make([]*Difference, len(d.Differences))
+	copy(diffs, d.Differences)
+	sort.SliceStable(diffs, func(x, y int) bool {
+		return diffs[x].Path.String() < diffs[y].Path.String()
+	})
+	rendered := make([]string, len(diffs))
+	for x, diff := range diffs {
+		rendered[x] = diff.format(opts)
+	}
+	if opts.MultiLine {
+		return strings.Join(rendered, "\n")
+	}
+	return strings.Join(rendered, "; ")
+}
+
+// String returns a single-line rendering of the difference, e.g.
+// "Spec.AllocatedStorage: 20 -> 50". See Delta.Format. String has a value
+// receiver so that sensitive values are redacted whether a Difference or a
+// pointer to one is formatted.
+func (d Difference) String() string {
+	return d.format(FormatOptions{})
+}
+
+// format returns a rendering of the difference using the supplied options
+func (d *Difference) format(opts FormatOptions) string {
+	path := d.Path.String()
+	if d.Path.isRoot() {
+		path = rootPath
+	}
+	va, vb := d.redacted(opts.SensitiveValueHashKey)
+	a := truncate(d.formatValue(va), opts.MaxValueLength)
+	b := truncate(d.formatValue(vb), opts.MaxValueLength)
+	if !opts.MultiLine {
+		return path + ": " + a + " -> " + b
+	}
+	lines := []string{path}
+	if !isNilValue(va) {
+		lines = append(lines, "- "+a)
+	}
+	if !isNilValue(vb) {
+		lines = append(lines, "+ "+b)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatValue returns a compact rendering of the supplied value of the
+// difference. Redacted values are rendered as is.
+func (d *Difference) formatValue(v any) string {
+	if redacted, ok := v.(string); ok && d.Sensitive {
+		return redacted
+	}
+	return formatValue(v)
+}
+
+// truncate shortens the supplied rendered value to the supplied maximum
+// number of characters
+func truncate(s string, max int) string {
+	if max == 0 {
+		max = DefaultMaxValueLength
+	}
+	if max < 0 || utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	keep := max - len(truncationMarker)
+	if keep < 0 {
+		keep = 0
+	}
+	return string([]rune(s)[:keep]) + truncationMarker
+}
+
+// formatValue returns a compact rendering of the supplied value
+func formatValue(v any) string {
+	var b strings.Builder
+	writeValue(&b, reflect.ValueOf(v), map[visit]bool{})
+	return b.String()
+}
+
+// stringerType is the reflected type of fmt.Stringer
+var stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+// writeValue writes a compact rendering of the supplied value. The supplied
+// map contains the pointers, maps and slices currently being rendered, and a
+// value reached again through a cycle is rendered as cycleValue.
+func writeValue(b *strings.Builder, v reflect.Value, visiting map[visit]bool) {
+	var entered []visit
+	defer func() {
+		for _, key := range entered {
+			delete(visiting, key)
+		}
+	}()
+	// enter returns false if the supplied value is already being rendered
+	enter := func(v reflect.Value) bool {
+		key, ok := visitOf(v, v)
+		if !ok {
+			return true
+		}
+		if visiting[key] {
+			return false
+		}
+		visiting[key] = true
+		entered = append(entered, key)
+		return true
+	}
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			b.WriteString(nilValue)
+			return
+		}
+		if !enter(v) {
+			b.WriteString(cycleValue)
+			return
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		b.WriteString(nilValue)
+		return
+	}
+	if !enter(v) {
+		b.WriteString(cycleValue)
+		return
+	}
+	if v.Type().Implements(stringerType) && v.CanInterface() {
+		// e.g. time.Time
+		fmt.Fprintf(b, "%q", v.Interface().(fmt.Stringer).String())
+		return
+	}
+	switch v.Kind() {
+	case reflect.String:
+		fmt.Fprintf(b, "%q", v.String())
+	case reflect.Struct:
+		b.WriteByte('{')
+		first := true
+		t := v.Type()
+		for x := 0; x < t.NumField(); x++ {
+			field := t.Field(x)
+			if !field.IsExported() || v.Field(x).IsZero() {
+				continue
+			}
+			if !first {
+				b.WriteByte(' ')
+			}
+			first = false
+			b.WriteString(field.Name)
+			b.WriteByte(':')
+			writeValue(b, v.Field(x), visiting)
+		}
+		b.WriteByte('}')
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			b.WriteString(nilValue)
+			return
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			fmt.Fprintf(b, "%q", bytesOf(v))
+			return
+		}
+		b.WriteByte('[')
+		for x := 0; x < v.Len(); x++ {
+			if x > 0 {
+				b.WriteByte(' ')
+			}
+			writeValue(b, v.Index(x), visiting)
+		}
+		b.WriteByte(']')
+	case reflect.Map:
+		if v.IsNil() {
+			b.WriteString(nilValue)
+			return
+		}
+		keys := v.MapKeys()
+		sort.Slice(keys, func(x, y int) bool {
+			return mapKeyPart(keys[x]) < mapKeyPart(keys[y])
+		})
+		b.WriteString("map[")
+		for x, k := range keys {
+			if x > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteString(mapKeyPart(k))
+			b.WriteByte(':')
+			writeValue(b, v.MapIndex(k), visiting)
+		}
+		b.WriteByte(']')
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		if v.IsNil() {
+			b.WriteString(nilValue)
+			return
+		}
+		b.WriteString(v.Type().String())
+	default:
+		if v.CanInterface() {
+			fmt.Fprint(b, v.Interface())
+			return
+		}
+		b.WriteString(v.Type().String())
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/pkg/compare"
+)
+
+func TestDelta_String(t *testing.T) {
+	require := require.New(t)
+
+	a := testResource{Spec: testSpec{
+		AllocatedStorage: int64P(20),
+		Config:           &testConfig{Name: strP("cfg")},
+		Rules:            []*testRule{{Port: int64P(80)}},
+	}}
+	b := testResource{Spec: testSpec{
+		AllocatedStorage:  int64P(50),
+		Engine:            strP("postgres"),
+		AvailabilityZones: []string{"us-west-2a", "us-west-2b"},
+		Rules:             []*testRule{{Port: int64P(80)}, {Port: int64P(443), Protocol: strP("tcp")}},
+		Labels:            map[string]*string{"team": strP("a"), "env": strP("dev")},
+	}}
+	delta, err := compare.Diff(a, b)
+	require.NoError(err)
+
+	require.Equal(
+		`Spec.AllocatedStorage: 20 -> 50; `+
+			`Spec.AvailabilityZones: <nil> -> ["us-west-2a" "us-west-2b"]; `+
+			`Spec.Config: {Name:"cfg"} -> <nil>; `+
+			`Spec.Engine: <nil> -> "postgres"; `+
+			`Spec.Labels: <nil> -> map[env:"dev" team:"a"]; `+
+			`Spec.Rules: [{Port:80}] -> [{Port:80} {Port:443 Protocol:"tcp"}]`,
+		delta.String(),
+	)
+
+	require.Equal(
+		"Spec.AllocatedStorage\n- 20\n+ 50\n"+
+			"Spec.AvailabilityZones\n+ [\"us-west-2a\" \"us-west-2b\"]\n"+
+			"Spec.Config\n- {Name:\"cfg\"}\n"+
+			"Spec.Engine\n+ \"postgres\"\n"+
+			"Spec.Labels\n+ map[env:\"dev\" team:\"a\"]\n"+
+			"Spec.Rules\n- [{Port:80}]\n+ [{Port:80} {Port:443 Protocol:\"tcp\"}]",
+		delta.Format(compare.FormatOptions{MultiLine: true}),
+	)
+
+	require.Equal("", compare.NewDelta().String())
+}
+
+func TestDelta_Format_Truncation(t *testing.T) {
+	require := require.New(t)
+
+	long := strings.Repeat("x", 100)
+	delta := compare.NewDelta()
+	delta.Add("Spec.PolicyDocument", strP("short"), &long)
+
+	rendered := delta.String()
+	require.Equal(
+		`Spec.PolicyDocument: "short" -> "`+strings.Repeat("x", 60)+"...",
+		rendered,
+	)
+	require.Equal(
+		`Spec.PolicyDocument: "short" -> "xxx...`,
+		delta.Format(compare.FormatOptions{MaxValueLength: 7}),
+	)
+	require.Equal(
+		`Spec.PolicyDocument: "short" -> "`+long+`"`,
+		delta.Format(compare.FormatOptions{MaxValueLength: -1}),
+	)
+}
+
+func TestDifference_String(t *testing.T) {
+	require := require.New(t)
+
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	cyclic := &testNode{Name: strP("a")}
+	cyclic.Next = cyclic
+	cyclic.Children = map[string]*testNode{"self": cyclic}
+	shared := &testNode{Name: strP("b")}
+	cyclicList := []interface{}{"a", nil}
+	cyclicList[1] = cyclicList
+	testCases := []struct {
+		path   string
+		a, b   interface{}
+		expect string
+	}{
+		{"Spec.Size", int64P(1), (*int64)(nil), "Spec.Size: 1 -> <nil>"},
+		{"Spec.Enabled", boolP(true), boolP(false), "Spec.Enabled: true -> false"},
+		{"Spec.Created", &created, nil, `Spec.Created: "2024-01-02 03:04:05 +0000 UTC" -> <nil>`},
+		{"Spec.Data", []byte("ab"), []byte("abc"), `Spec.Data: "ab" -> "abc"`},
+		{"Spec.Rules[0].Port", 80, 443, "Spec.Rules[0].Port: 80 -> 443"},
+		{"", "a", "b", `<root>: "a" -> "b"`},
+		// Values reached again through a cycle are not rendered again
+		{
+			"Spec.Node", cyclic, nil,
+			`Spec.Node: {Name:"a" Next:<cycle> Children:map[self:<cycle>]} -> <nil>`,
+		},
+		{"Spec.List", cyclicList, nil, `Spec.List: ["a" <cycle>] -> <nil>`},
+		// ... but shared values are
+		{
+			"Spec.Node", &testNode{Next: shared, Children: map[string]*testNode{"x": shared}}, nil,
+			`Spec.Node: {Next:{Name:"b"} Children:map[x:{Name:"b"}]} -> <nil>`,
+		},
+	}
+	for _, tc := range testCases {
+		delta := compare.NewDelta()
+		delta.Add(tc.path, tc.a, tc.b)
+		require.Equal(tc.expect, delta.Differences[0].String())
+	}
+}
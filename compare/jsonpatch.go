@@ -0,0 +1,182 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/aws-controllers-k8s/pkg/names"
+)
+
+const (
+	// JSONPatchOpAdd is the RFC 6902 operation adding a value
+	JSONPatchOpAdd = "add"
+	// JSONPatchOpRemove is the RFC 6902 operation removing a value
+	JSONPatchOpRemove = "remove"
+	// JSONPatchOpReplace is the RFC 6902 operation replacing a value
+	JSONPatchOpReplace = "replace"
+)
+
+// JSONPatchOperation is a single RFC 6902 JSON Patch operation
+type JSONPatchOperation struct {
+	// Op is one of JSONPatchOpAdd, JSONPatchOpRemove or JSONPatchOpReplace
+	Op string `json:"op"`
+	// Path is the RFC 6901 JSON Pointer to the value being changed
+	Path string `json:"path"`
+	// Value is the new value. It is omitted for remove operations.
+	Value interface{} `json:"value,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler. The value member is omitted for
+// remove operations only, so that a null value can be added or replaced.
+func (o JSONPatchOperation) MarshalJSON() ([]byte, error) {
+	type operation struct {
+		Op    string       `json:"op"`
+		Path  string       `json:"path"`
+		Value *interface{} `json:"value,omitempty"`
+	}
+	out := operation{Op: o.Op, Path: o.Path}
+	if o.Op != JSONPatchOpRemove {
+		out.Value = &o.Value
+	}
+	return json.Marshal(out)
+}
+
+// JSONPatchOperations returns the RFC 6902 operations that, applied to the
+// JSON representation of the first resource under comparison, produce the
+// JSON representation of the second one. One operation is returned for each
+// Difference:
+//
+//   - "add" for a Difference of kind KindAdded
+//   - "remove" for a Difference of kind KindRemoved, or whose B value is nil
+//   - "replace" otherwise
+//
+// List elements selected by index are always replaced, so that no operation
+// shifts the indexes used by another. List elements selected by key (see
+// KeyedSlice) are added to the end of the list, and removed.
+//
+// The values of sensitive Differences (see SensitivePaths) are replaced by
+// RedactedValue.
+//
+// The JSON Pointer of Differences recorded by Diff is built from the json
+// struct tags of the compared types. For Differences added with Delta.Add,
+// each field name is converted using names.New(...).CamelLower, which matches
+// the json tags of ACK custom resources.
+func (d *Delta) JSONPatchOperations() []JSONPatchOperation {
+	ops := make([]JSONPatchOperation, 0, len(d.Differences))
+	for _, diff := range d.Differences {
+		ops = append(ops, diff.jsonPatchOperation())
+	}
+	return ops
+}
+
+// JSONPatch returns the JSON encoding of the RFC 6902 patch described by
+// JSONPatchOperations, suitable for use with a Kubernetes JSON patch. An
+// error is returned if the Delta contains a sensitive Difference, since the
+// patch could neither contain its real value nor a redacted one, or an
+// Embedded Difference, since its Path does not exist in the JSON
+// representation of the resource (see EmbeddedDocuments).
+func (d *Delta) JSONPatch() ([]byte, error) {
+	if diff := d.hasSensitive(); diff != nil {
+		return nil, fmt.Errorf(
+			"cannot include sensitive difference at %s in a JSON patch",
+			diff.Path,
+		)
+	}
+	if diff := d.hasEmbedded(); diff != nil {
+		return nil, fmt.Errorf(
+			"cannot include embedded document difference at %s in a JSON patch",
+			diff.Path,
+		)
+	}
+	return json.Marshal(d.JSONPatchOperations())
+}
+
+// jsonPatchOperation returns the RFC 6902 operation for the difference
+func (d *Difference) jsonPatchOperation() JSONPatchOperation {
+	parts := d.Path.parts
+	op := JSONPatchOperation{Path: d.jsonPointer()}
+	_, b := d.redacted(nil)
+	switch {
+	case len(parts) > 0 && isIndexPart(parts[len(parts)-1]):
+		op.Op = JSONPatchOpReplace
+		op.Value = b
+	case d.kind() == KindAdded:
+		op.Op = JSONPatchOpAdd
+		op.Value = b
+	case d.kind() == KindRemoved || isNilValue(d.B):
+		op.Op = JSONPatchOpRemove
+	default:
+		op.Op = JSONPatchOpReplace
+		op.Value = b
+	}
+	return op
+}
+
+// jsonPointer returns the RFC 6901 JSON Pointer to the difference
+func (d *Difference) jsonPointer() string {
+	var b strings.Builder
+	for _, name := range d.jsonParts() {
+		b.WriteByte('/')
+		b.WriteString(jsonPointerEscaper.Replace(name))
+	}
+	return b.String()
+}
+
+// jsonParts returns the JSON object member names and array indexes leading to
+// the difference. An empty slice refers to the root of the compared values.
+func (d *Difference) jsonParts() []string {
+	parts := d.Path.parts
+	if d.Path.isRoot() {
+		return []string{}
+	}
+	if len(d.jsonPath) == len(parts) {
+		return d.jsonPath
+	}
+	members := make([]string, len(parts))
+	for x, part := range parts {
+		members[x] = jsonName(part)
+	}
+	return members
+}
+
+// jsonPointerEscaper escapes the characters with special meaning in a JSON
+// Pointer reference token
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// jsonName returns the JSON object member name or array index for a Path part
+// whose json struct tag is unknown
+func jsonName(part string) string {
+	if isElementPart(part) {
+		return part[1 : len(part)-1]
+	}
+	return names.Cached(part).CamelLower
+}
+
+// isNilValue returns true if the supplied value is nil or a typed nil
+func isNilValue(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice,
+		reflect.Chan, reflect.Func:
+		return rv.IsNil()
+	}
+	return false
+}
@@ -0,0 +1,246 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/pkg/compare"
+)
+
+type patchRule struct {
+	Port     *int64  `json:"port,omitempty"`
+	Protocol *string `json:"protocol,omitempty"`
+}
+
+type patchConfig struct {
+	Name    *string `json:"name,omitempty"`
+	Enabled *bool   `json:"enabled,omitempty"`
+}
+
+type patchSpec struct {
+	DBInstanceIdentifier *string            `json:"dbInstanceIdentifier,omitempty"`
+	AllocatedStorage     *int64             `json:"allocatedStorage,omitempty"`
+	Config               *patchConfig       `json:"config,omitempty"`
+	Rules                []*patchRule       `json:"rules,omitempty"`
+	Zones                []string           `json:"zones,omitempty"`
+	Tags                 map[string]*string `json:"tags,omitempty"`
+}
+
+type patchResource struct {
+	Spec patchSpec `json:"spec"`
+}
+
+// applyJSONPatch applies the supplied RFC 6902 add, remove and replace
+// operations to the supplied JSON document
+func applyJSONPatch(t *testing.T, doc []byte, patch []byte) []byte {
+	var root interface{}
+	require.NoError(t, json.Unmarshal(doc, &root))
+	var ops []map[string]interface{}
+	require.NoError(t, json.Unmarshal(patch, &ops))
+
+	for _, op := range ops {
+		pointer := op["path"].(string)
+		if op["op"] != "remove" {
+			_, ok := op["value"]
+			require.True(t, ok, "missing value for %s", pointer)
+		}
+		if pointer == "" {
+			root = op["value"]
+			continue
+		}
+		tokens := strings.Split(pointer, "/")[1:]
+		for x, token := range tokens {
+			token = strings.ReplaceAll(token, "~1", "/")
+			tokens[x] = strings.ReplaceAll(token, "~0", "~")
+		}
+		root = applyJSONPatchOperation(t, root, tokens, op)
+	}
+	out, err := json.Marshal(root)
+	require.NoError(t, err)
+	return out
+}
+
+// applyJSONPatchOperation applies the supplied operation at the supplied
+// reference tokens beneath the supplied node, returning the updated node
+func applyJSONPatchOperation(
+	t *testing.T,
+	node interface{},
+	tokens []string,
+	op map[string]interface{},
+) interface{} {
+	token := tokens[0]
+	last := len(tokens) == 1
+	switch n := node.(type) {
+	case map[string]interface{}:
+		_, exists := n[token]
+		switch {
+		case !last:
+			require.True(t, exists, "missing member %s", token)
+			n[token] = applyJSONPatchOperation(t, n[token], tokens[1:], op)
+		case op["op"] == "remove":
+			require.True(t, exists, "remove of missing member %s", token)
+			delete(n, token)
+		case op["op"] == "replace":
+			require.True(t, exists, "replace of missing member %s", token)
+			n[token] = op["value"]
+		default:
+			n[token] = op["value"]
+		}
+		return n
+	case []interface{}:
+		if last && op["op"] == "add" && token == "-" {
+			return append(n, op["value"])
+		}
+		index, err := strconv.Atoi(token)
+		require.NoError(t, err)
+		require.True(t, index >= 0 && index < len(n), "index %d out of range", index)
+		switch {
+		case !last:
+			n[index] = applyJSONPatchOperation(t, n[index], tokens[1:], op)
+		case op["op"] == "remove":
+			return append(n[:index:index], n[index+1:]...)
+		case op["op"] == "replace":
+			n[index] = op["value"]
+		default:
+			return append(n[:index:index], append([]interface{}{op["value"]}, n[index:]...)...)
+		}
+		return n
+	}
+	require.Failf(t, "unreachable path", "%v", tokens)
+	return nil
+}
+
+func TestDelta_JSONPatch_RoundTrip(t *testing.T) {
+	base := func() patchResource {
+		return patchResource{
+			Spec: patchSpec{
+				DBInstanceIdentifier: strP("db-1"),
+				AllocatedStorage:     int64P(20),
+				Config:               &patchConfig{Name: strP("cfg")},
+				Rules: []*patchRule{
+					{Port: int64P(80), Protocol: strP("tcp")},
+					{Port: int64P(443), Protocol: strP("tcp")},
+				},
+				Zones: []string{"us-west-2a"},
+				Tags: map[string]*string{
+					"env":       strP("dev"),
+					"team/name": strP("ack"),
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		modify func(*patchResource)
+	}{
+		{"equal", func(r *patchResource) {}},
+		{"replace scalar", func(r *patchResource) {
+			r.Spec.AllocatedStorage = int64P(40)
+		}},
+		{"add scalar", func(r *patchResource) {
+			r.Spec.Config.Enabled = boolP(true)
+		}},
+		{"remove scalar", func(r *patchResource) {
+			r.Spec.DBInstanceIdentifier = nil
+		}},
+		{"remove struct", func(r *patchResource) {
+			r.Spec.Config = nil
+		}},
+		{"replace list element field", func(r *patchResource) {
+			r.Spec.Rules[1].Port = int64P(8443)
+		}},
+		{"replace list element with null", func(r *patchResource) {
+			r.Spec.Rules[0] = nil
+		}},
+		{"replace list", func(r *patchResource) {
+			r.Spec.Rules = r.Spec.Rules[:1]
+			r.Spec.Zones = []string{"us-west-2b", "us-west-2c"}
+		}},
+		{"map keys", func(r *patchResource) {
+			r.Spec.Tags["env"] = strP("prod")
+			r.Spec.Tags["owner~id"] = strP("1234")
+			delete(r.Spec.Tags, "team/name")
+		}},
+		{"remove map", func(r *patchResource) {
+			r.Spec.Tags = nil
+		}},
+	}
+
+	for _, tc := range testCases {
+		a := base()
+		b := base()
+		tc.modify(&b)
+
+		delta, err := compare.Diff(a, b)
+		require.NoError(t, err)
+		patch, err := delta.JSONPatch()
+		require.NoError(t, err)
+
+		aJSON, err := json.Marshal(a)
+		require.NoError(t, err)
+		patched := applyJSONPatch(t, aJSON, patch)
+
+		var got patchResource
+		require.NoError(t, json.Unmarshal(patched, &got))
+		assert.Equal(t, b, got, fmt.Sprintf("for %s with patch %s", tc.name, patch))
+	}
+}
+
+func TestDelta_JSONPatchOperations(t *testing.T) {
+	require := require.New(t)
+
+	a := patchResource{Spec: patchSpec{
+		AllocatedStorage: int64P(20),
+		Rules:            []*patchRule{{Port: int64P(80)}},
+		Tags:             map[string]*string{"a/b": strP("1")},
+	}}
+	b := patchResource{Spec: patchSpec{
+		DBInstanceIdentifier: strP("db-1"),
+		Rules:                []*patchRule{{Port: int64P(443)}},
+		Tags:                 map[string]*string{"a/b": strP("2")},
+	}}
+	delta, err := compare.Diff(a, b)
+	require.NoError(err)
+	require.Equal(
+		[]compare.JSONPatchOperation{
+			{Op: "add", Path: "/spec/dbInstanceIdentifier", Value: b.Spec.DBInstanceIdentifier},
+			{Op: "remove", Path: "/spec/allocatedStorage"},
+			{Op: "replace", Path: "/spec/rules/0/port", Value: b.Spec.Rules[0].Port},
+			{Op: "replace", Path: "/spec/tags/a~1b", Value: b.Spec.Tags["a/b"]},
+		},
+		delta.JSONPatchOperations(),
+	)
+
+	// Differences added by hand map field names to lowerCamelCase
+	delta = compare.NewDelta()
+	delta.Add("Spec.DBInstanceIdentifier", strP("db-1"), strP("db-2"))
+	delta.Add("Spec.Rules[0].Port", int64P(80), int64P(443))
+	delta.Add("Spec.Rules[1]", &patchRule{}, nil)
+	patch, err := delta.JSONPatch()
+	require.NoError(err)
+	require.JSONEq(`[
+		{"op": "replace", "path": "/spec/dbInstanceIdentifier", "value": "db-2"},
+		{"op": "replace", "path": "/spec/rules/0/port", "value": 443},
+		{"op": "replace", "path": "/spec/rules/1", "value": null}
+	]`, string(patch))
+}
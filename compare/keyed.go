@@ -0,0 +1,188 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// keyedSlice identifies the lists that are compared as sets of elements keyed
+// by a field
+type keyedSlice struct {
+	pattern  pathPattern
+	keyField string
+}
+
+// KeyedSlice compares the lists of structs (or pointers to structs) at the
+// supplied dotted-notation path pattern as sets of elements identified by the
+// value of the named key field, instead of element by element. For example:
+//
+//	compare.KeyedSlice("Spec.Tags", "Key")
+//	compare.KeyedSlice("Spec.IngressRules", "FromPort")
+//	compare.KeyedSlice("Spec.Listeners[*].Rules", "RuleARN")
+//
+// Elements present in both lists are compared recursively, recording element
+// Paths such as "Spec.Tags[Key=env].Value". An element present in only one
+// list is recorded as a single Difference whose A or B value is nil, e.g. at
+// "Spec.Tags[Key=owner]". The order of the elements does not matter.
+//
+// If an element of either list is nil, has a nil key or shares its key with
+// another element of the same list, the lists are compared as if the option
+// had not been supplied.
+func KeyedSlice(pattern, keyField string) Option {
+	return func(o *options) {
+		o.keyed = append(o.keyed, keyedSlice{newPathPattern(pattern), keyField})
+	}
+}
+
+// keyField returns the name of the key field for the list at the supplied
+// path, or "" if the list is not keyed
+func (d *differ) keyField(path []pathPart) string {
+	if len(d.opts.keyed) == 0 {
+		return ""
+	}
+	parts := partNames(path)
+	for _, keyed := range d.opts.keyed {
+		if keyed.pattern.matches(parts) {
+			return keyed.keyField
+		}
+	}
+	return ""
+}
+
+// diffKeyed records the differences between the supplied lists as sets of
+// elements keyed by the supplied field. It returns false, without recording
+// any differences, if the elements cannot be keyed.
+//
+// Differences are recorded for the elements present in both lists first, then
+// for the elements added to the second list, then for the elements removed
+// from the first list in descending index order, so that the corresponding
+// JSON Patch operations never shift the index used by a later operation.
+func (d *differ) diffKeyed(
+	path []pathPart,
+	keyField string,
+	a, b reflect.Value,
+) bool {
+	aKeys, ok := elementKeys(a, keyField)
+	if !ok {
+		return false
+	}
+	bKeys, ok := elementKeys(b, keyField)
+	if !ok {
+		return false
+	}
+	bIndexes := make(map[string]int, len(bKeys))
+	for y, key := range bKeys {
+		bIndexes[key] = y
+	}
+	aIndexes := make(map[string]int, len(aKeys))
+	for x, key := range aKeys {
+		aIndexes[key] = x
+		if y, ok := bIndexes[key]; ok {
+			d.diff(
+				append(path, pathPart{keyedPart(keyField, key), strconv.Itoa(x)}),
+				a.Index(x), b.Index(y),
+			)
+		}
+	}
+	// As for map keys, elements present in only one list are skipped if
+	// ignored, or if unset in the first list and unset values are ignored
+	for y, key := range bKeys {
+		if _, ok := aIndexes[key]; !ok {
+			elemPath := append(path, pathPart{keyedPart(keyField, key), "-"})
+			if d.ignored(elemPath) || d.opts.ignoreUnsetInDesired {
+				continue
+			}
+			d.add(elemPath, nil, b.Index(y).Interface())
+		}
+	}
+	for x := len(aKeys) - 1; x >= 0; x-- {
+		key := aKeys[x]
+		if _, ok := bIndexes[key]; !ok {
+			elemPath := append(path, pathPart{keyedPart(keyField, key), strconv.Itoa(x)})
+			if d.ignored(elemPath) {
+				continue
+			}
+			d.add(elemPath, a.Index(x).Interface(), nil)
+		}
+	}
+	return true
+}
+
+// elementKeys returns the value of the named key field of each element of the
+// supplied list, formatted as a string. It returns false if any element or
+// key is nil, the elements have no such field, or two elements share a key.
+func elementKeys(list reflect.Value, keyField string) ([]string, bool) {
+	keys := make([]string, list.Len())
+	seen := make(map[string]bool, list.Len())
+	for x := 0; x < list.Len(); x++ {
+		key, ok := elementKey(list.Index(x), keyField)
+		if !ok || seen[key] {
+			return nil, false
+		}
+		seen[key] = true
+		keys[x] = key
+	}
+	return keys, true
+}
+
+// elementKey returns the value of the named key field of the supplied list
+// element, formatted as a string
+func elementKey(elem reflect.Value, keyField string) (string, bool) {
+	elem, ok := derefValue(elem)
+	if !ok || elem.Kind() != reflect.Struct {
+		return "", false
+	}
+	field := elem.FieldByName(keyField)
+	if !field.IsValid() {
+		return "", false
+	}
+	field, ok = derefValue(field)
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprint(field.Interface()), true
+}
+
+// derefValue returns the value pointed to by the supplied pointer or held by
+// the supplied interface, recursively. It returns false if a nil pointer or
+// interface is encountered.
+func derefValue(v reflect.Value) (reflect.Value, bool) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return v, false
+		}
+		v = v.Elem()
+	}
+	return v, true
+}
+
+// keyedPart returns the Path part selecting the list element with the
+// supplied key, e.g. "[Key=env]"
+func keyedPart(keyField, key string) string {
+	return "[" + keyField + "=" + key + "]"
+}
+
+// parseKeyedPart returns the key field and key of the supplied Path part, or
+// false if the part does not select a list element by key
+func parseKeyedPart(part string) (string, string, bool) {
+	if !isElementPart(part) {
+		return "", "", false
+	}
+	keyField, key, ok := strings.Cut(part[1:len(part)-1], "=")
+	return keyField, key, ok
+}
@@ -0,0 +1,210 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/pkg/compare"
+)
+
+func tag(key, value string) *testTag {
+	return &testTag{Key: strP(key), Value: strP(value)}
+}
+
+func TestDiff_KeyedSlice(t *testing.T) {
+	require := require.New(t)
+
+	a := testResource{Spec: testSpec{
+		Tags: []*testTag{tag("env", "dev"), tag("team", "a"), tag("owner", "b")},
+	}}
+
+	testCases := []struct {
+		name   string
+		tags   []*testTag
+		expect []string
+	}{
+		{
+			"reordered",
+			[]*testTag{tag("owner", "b"), tag("env", "dev"), tag("team", "a")},
+			[]string{},
+		},
+		{
+			"modified",
+			[]*testTag{tag("team", "a"), tag("owner", "b"), tag("env", "prod")},
+			[]string{"Spec.Tags[Key=env].Value"},
+		},
+		{
+			"added and removed",
+			[]*testTag{tag("cost", "1"), tag("owner", "b"), tag("env", "dev"), tag("app", "x")},
+			[]string{
+				"Spec.Tags[Key=cost]", "Spec.Tags[Key=app]", "Spec.Tags[Key=team]",
+			},
+		},
+		{
+			"removed in descending order",
+			[]*testTag{tag("team", "a")},
+			[]string{"Spec.Tags[Key=owner]", "Spec.Tags[Key=env]"},
+		},
+		{
+			"nil key falls back to positional comparison",
+			[]*testTag{tag("env", "dev"), {Value: strP("a")}, tag("owner", "b")},
+			[]string{"Spec.Tags[1].Key"},
+		},
+		{
+			"duplicate key falls back to positional comparison",
+			[]*testTag{tag("env", "dev"), tag("env", "prod"), tag("owner", "b")},
+			[]string{"Spec.Tags[1].Key", "Spec.Tags[1].Value"},
+		},
+	}
+	for _, tc := range testCases {
+		b := testResource{Spec: testSpec{Tags: tc.tags}}
+		delta, err := compare.Diff(a, b, compare.KeyedSlice("Spec.Tags", "Key"))
+		require.NoError(err)
+		paths := []string{}
+		for _, diff := range delta.Differences {
+			paths = append(paths, diff.Path.String())
+		}
+		require.Equal(tc.expect, paths, tc.name)
+	}
+
+	b := testResource{Spec: testSpec{
+		Tags: []*testTag{tag("env", "dev"), tag("owner", "b"), tag("cost", "1")},
+	}}
+	delta, err := compare.Diff(a, b, compare.KeyedSlice("Spec.*", "Key"))
+	require.NoError(err)
+	require.Len(delta.Differences, 2)
+	require.True(delta.DifferentAt("Spec.Tags[Key=cost]"))
+	require.True(delta.DifferentAt("Spec.Tags[Key=team]"))
+	for _, diff := range delta.Differences {
+		if diff.Path.String() == "Spec.Tags[Key=cost]" {
+			require.Nil(diff.A)
+			require.Equal(tag("cost", "1"), diff.B)
+		} else {
+			require.Equal(tag("team", "a"), diff.A)
+			require.Nil(diff.B)
+		}
+	}
+
+	// Without the option the lists are compared positionally
+	delta, err = compare.Diff(a, b)
+	require.NoError(err)
+	require.True(delta.DifferentAt("Spec.Tags[1].Key"))
+}
+
+func TestDiff_KeyedSlice_Ignored(t *testing.T) {
+	require := require.New(t)
+
+	desired := testResource{Spec: testSpec{
+		Tags: []*testTag{tag("env", "dev"), tag("team", "a")},
+	}}
+	latest := testResource{Spec: testSpec{
+		Tags: []*testTag{tag("owner", "b"), tag("env", "dev")},
+	}}
+
+	testCases := []struct {
+		name   string
+		opts   []compare.Option
+		expect []string
+	}{
+		{"none", nil, []string{"Spec.Tags[Key=owner]", "Spec.Tags[Key=team]"}},
+		{
+			"ignore added key",
+			[]compare.Option{compare.IgnorePaths("Spec.Tags[Key=owner]")},
+			[]string{"Spec.Tags[Key=team]"},
+		},
+		{
+			"ignore removed key",
+			[]compare.Option{compare.IgnorePaths("Spec.Tags[Key=team]")},
+			[]string{"Spec.Tags[Key=owner]"},
+		},
+		{
+			"ignore all elements",
+			[]compare.Option{compare.IgnorePaths("Spec.Tags[*]")},
+			[]string{},
+		},
+		{
+			"ignore unset in desired",
+			[]compare.Option{compare.IgnoreUnsetInDesired()},
+			[]string{"Spec.Tags[Key=team]"},
+		},
+	}
+	for _, tc := range testCases {
+		opts := append([]compare.Option{compare.KeyedSlice("Spec.Tags", "Key")}, tc.opts...)
+		delta, err := compare.Diff(desired, latest, opts...)
+		require.NoError(err)
+		paths := []string{}
+		for _, diff := range delta.Differences {
+			paths = append(paths, diff.Path.String())
+		}
+		require.Equal(tc.expect, paths, tc.name)
+	}
+}
+
+func TestDiff_KeyedSlice_RoundTrip(t *testing.T) {
+	rule := func(port int64, protocol string) *patchRule {
+		return &patchRule{Port: int64P(port), Protocol: strP(protocol)}
+	}
+	a := patchResource{Spec: patchSpec{Rules: []*patchRule{
+		rule(22, "tcp"), rule(80, "tcp"), rule(443, "tcp"), rule(53, "udp"),
+	}}}
+
+	testCases := []struct {
+		name  string
+		rules []*patchRule
+	}{
+		{"modified", []*patchRule{
+			rule(22, "tcp"), rule(80, "udp"), rule(443, "tcp"), rule(53, "udp"),
+		}},
+		{"added", []*patchRule{
+			rule(22, "tcp"), rule(80, "tcp"), rule(443, "tcp"), rule(53, "udp"),
+			rule(8080, "tcp"),
+		}},
+		{"removed", []*patchRule{rule(80, "tcp"), rule(53, "udp")}},
+		{"mixed", []*patchRule{
+			rule(8443, "tcp"), rule(443, "udp"), rule(22, "tcp"), rule(3306, "tcp"),
+		}},
+	}
+	for _, tc := range testCases {
+		b := patchResource{Spec: patchSpec{Rules: tc.rules}}
+		delta, err := compare.Diff(a, b, compare.KeyedSlice("Spec.Rules", "Port"))
+		require.NoError(t, err)
+
+		// Applying the JSON Patch to A yields B, ignoring the order of the
+		// rules
+		patch, err := delta.JSONPatch()
+		require.NoError(t, err)
+		aJSON, err := json.Marshal(a)
+		require.NoError(t, err)
+		var patched patchResource
+		require.NoError(t, json.Unmarshal(applyJSONPatch(t, aJSON, patch), &patched))
+		assert.ElementsMatch(
+			t, b.Spec.Rules, patched.Spec.Rules,
+			fmt.Sprintf("for %s with patch %s", tc.name, patch),
+		)
+
+		// Applying the delta to A yields B, ignoring the order of the rules
+		target := patchResource{Spec: patchSpec{Rules: append([]*patchRule{}, a.Spec.Rules...)}}
+		for x, r := range target.Spec.Rules {
+			target.Spec.Rules[x] = rule(*r.Port, *r.Protocol)
+		}
+		require.NoError(t, delta.ApplyTo(&target, compare.SideB))
+		assert.ElementsMatch(t, b.Spec.Rules, target.Spec.Rules, tc.name)
+	}
+}
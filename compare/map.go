@@ -15,26 +15,66 @@ package compare
 
 // MapStringStringPEqual returns true if the supplied maps are equal
 func MapStringStringPEqual(a, b map[string]*string) bool {
-	if len(a) != len(b) {
-		return false
-	}
-	for aKey, aVal := range a {
-		if bVal, ok := b[aKey]; !ok || *bVal != *aVal {
-			return false
-		}
+	return MapPEqual(a, b)
+}
+
+// MapStringStringPEqualWithOptions returns true if the supplied maps are
+// equal. The supplied Options are applied to the comparison, e.g.
+// IgnorePaths("owner") ignores the value of the "owner" key.
+func MapStringStringPEqualWithOptions(
+	a, b map[string]*string,
+	opts ...Option,
+) bool {
+	if len(opts) > 0 {
+		return equalWithOptions(a, b, opts)
 	}
-	return true
+	return MapStringStringPEqual(a, b)
 }
 
 // MapStringStringEqual returns true if the supplied maps are equal
 func MapStringStringEqual(a, b map[string]string) bool {
+	return MapEqual(a, b)
+}
+
+// MapStringStringEqualWithOptions returns true if the supplied maps are equal.
+// The supplied Options are applied to the comparison, e.g.
+// IgnorePaths("owner") ignores the value of the "owner" key.
+func MapStringStringEqualWithOptions(
+	a, b map[string]string,
+	opts ...Option,
+) bool {
+	if len(opts) > 0 {
+		return equalWithOptions(a, b, opts)
+	}
+	return MapStringStringEqual(a, b)
+}
+
+// MapEqual returns true if the supplied maps have the same keys with equal
+// values. A nil map is equal to an empty one.
+func MapEqual[K, V comparable](a, b map[K]V) bool {
+	return MapEqualFunc(a, b, func(x, y V) bool { return x == y })
+}
+
+// MapEqualFunc returns true if the supplied maps have the same keys with
+// values for which the supplied function returns true. A nil map is equal to
+// an empty one.
+func MapEqualFunc[K comparable, V any](a, b map[K]V, eq func(V, V) bool) bool {
 	if len(a) != len(b) {
 		return false
 	}
 	for aKey, aVal := range a {
-		if bVal, ok := b[aKey]; !ok || bVal != aVal {
+		if bVal, ok := b[aKey]; !ok || !eq(aVal, bVal) {
 			return false
 		}
 	}
 	return true
 }
+
+// MapPEqual returns true if the supplied maps of pointers have the same keys
+// pointing to equal values. See PEqual for the treatment of nil pointers and
+// the Options that apply.
+func MapPEqual[K, V comparable](a, b map[K]*V, opts ...Option) bool {
+	return MapEqualFunc(a, b, func(x, y *V) bool {
+		return PEqual(x, y, opts...)
+	})
+}
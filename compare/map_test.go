@@ -93,3 +93,40 @@ func TestMapStringStringEqual(t *testing.T) {
 	require.True(compare.MapStringStringEqual(ab, ba))
 	require.True(compare.MapStringStringEqual(ab, abc))
 }
+
+func TestMapEqual(t *testing.T) {
+	require := require.New(t)
+
+	require.True(compare.MapEqual(map[string]int64{}, nil))
+	require.True(compare.MapEqual(
+		map[string]int64{"a": 1, "b": 2}, map[string]int64{"b": 2, "a": 1},
+	))
+	require.False(compare.MapEqual(
+		map[string]int64{"a": 1, "b": 2}, map[string]int64{"a": 1, "b": 3},
+	))
+	require.False(compare.MapEqual(
+		map[string]int64{"a": 1, "b": 2}, map[string]int64{"a": 1, "c": 2},
+	))
+
+	sameLength := func(x, y []string) bool { return len(x) == len(y) }
+	require.True(compare.MapEqualFunc(
+		map[string][]string{"a": {"x"}}, map[string][]string{"a": {"y"}}, sameLength,
+	))
+	require.False(compare.MapEqualFunc(
+		map[string][]string{"a": {"x"}}, map[string][]string{"a": {}}, sameLength,
+	))
+}
+
+func TestMapPEqual(t *testing.T) {
+	require := require.New(t)
+
+	require.True(compare.MapPEqual(
+		map[string]*bool{"a": boolP(true)}, map[string]*bool{"a": boolP(true)},
+	))
+	require.False(compare.MapPEqual(
+		map[string]*bool{"a": boolP(true)}, map[string]*bool{"a": boolP(false)},
+	))
+	require.False(compare.MapPEqual(
+		map[string]*bool{"a": boolP(true)}, map[string]*bool{"b": boolP(true)},
+	))
+}
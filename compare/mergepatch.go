@@ -0,0 +1,112 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// MergePatch returns an RFC 7386 JSON Merge Patch that, applied to the JSON
+// representation of the first resource under comparison, produces the JSON
+// representation of the second one. The B value of each Difference is nested
+// into a JSON object along the Difference's Path, using the same JSON member
+// names as JSONPatch. Differences whose B value is nil are represented as
+// null, which removes the field.
+//
+// A merge patch cannot address individual list elements: a list in a merge
+// patch always replaces the target list wholesale. Diff only records a
+// Difference for a whole list when the lists differ in length or contain
+// scalar values, and otherwise records Differences within the list elements
+// (e.g. "Spec.Rules[0].Port"). MergePatch returns an error for any Difference
+// whose Path passes through a list element, since the complete list is not
+// known; use JSONPatch for such Deltas.
+//
+// An error is also returned if the Delta contains Differences at both a Path
+// and a Path beneath it, e.g. "Spec.Config" and "Spec.Config.Name", a
+// sensitive Difference (see SensitivePaths) or an Embedded Difference (see
+// EmbeddedDocuments).
+func (d *Delta) MergePatch() ([]byte, error) {
+	if diff := d.hasSensitive(); diff != nil {
+		return nil, fmt.Errorf(
+			"cannot include sensitive difference at %s in a merge patch",
+			diff.Path,
+		)
+	}
+	if diff := d.hasEmbedded(); diff != nil {
+		return nil, fmt.Errorf(
+			"cannot include embedded document difference at %s in a merge patch",
+			diff.Path,
+		)
+	}
+	var root interface{} = map[string]interface{}{}
+	for _, diff := range d.Differences {
+		for _, part := range diff.Path.parts {
+			if isElementPart(part) {
+				return nil, fmt.Errorf(
+					"cannot represent difference at %s in a merge patch: "+
+						"merge patches replace lists wholesale",
+					diff.Path,
+				)
+			}
+		}
+		parts := diff.jsonParts()
+		if len(parts) == 0 {
+			// A difference at the root replaces the whole document
+			if len(d.Differences) > 1 {
+				return nil, fmt.Errorf(
+					"conflicting differences at the root and beneath it",
+				)
+			}
+			root = diff.B
+			break
+		}
+		obj := root.(map[string]interface{})
+		for x, part := range parts[:len(parts)-1] {
+			next, ok := obj[part]
+			if !ok {
+				next = map[string]interface{}{}
+				obj[part] = next
+			}
+			nextObj, ok := next.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf(
+					"conflicting differences at %s and %s",
+					strings.Join(diff.Path.parts[:x+1], "."), diff.Path,
+				)
+			}
+			obj = nextObj
+		}
+		last := parts[len(parts)-1]
+		if _, ok := obj[last]; ok {
+			return nil, fmt.Errorf(
+				"conflicting differences at and beneath %s", diff.Path,
+			)
+		}
+		obj[last] = mergePatchValue{diff.B}
+	}
+	return json.Marshal(root)
+}
+
+// mergePatchValue wraps the B value of a Difference within a merge patch
+// document, so that it is never mistaken for an object created by MergePatch
+type mergePatchValue struct {
+	value interface{}
+}
+
+// MarshalJSON implements json.Marshaler
+func (v mergePatchValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.value)
+}
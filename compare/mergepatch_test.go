@@ -0,0 +1,154 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/pkg/compare"
+)
+
+// applyMergePatch applies the supplied RFC 7386 merge patch to the supplied
+// JSON document
+func applyMergePatch(target, patch interface{}) interface{} {
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	targetObj, ok := target.(map[string]interface{})
+	if !ok {
+		targetObj = map[string]interface{}{}
+	}
+	for k, v := range patchObj {
+		if v == nil {
+			delete(targetObj, k)
+			continue
+		}
+		targetObj[k] = applyMergePatch(targetObj[k], v)
+	}
+	return targetObj
+}
+
+func TestDelta_MergePatch_RoundTrip(t *testing.T) {
+	base := func() patchResource {
+		return patchResource{
+			Spec: patchSpec{
+				DBInstanceIdentifier: strP("db-1"),
+				AllocatedStorage:     int64P(20),
+				Config:               &patchConfig{Name: strP("cfg")},
+				Rules:                []*patchRule{{Port: int64P(80)}},
+				Zones:                []string{"us-west-2a"},
+				Tags:                 map[string]*string{"env": strP("dev")},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		modify func(*patchResource)
+	}{
+		{"equal", func(r *patchResource) {}},
+		{"replace scalar", func(r *patchResource) {
+			r.Spec.AllocatedStorage = int64P(40)
+		}},
+		{"add nested scalar", func(r *patchResource) {
+			r.Spec.Config.Enabled = boolP(true)
+		}},
+		{"remove scalar", func(r *patchResource) {
+			r.Spec.DBInstanceIdentifier = nil
+		}},
+		{"remove struct", func(r *patchResource) {
+			r.Spec.Config = nil
+		}},
+		{"replace lists", func(r *patchResource) {
+			r.Spec.Rules = append(r.Spec.Rules, &patchRule{Port: int64P(443)})
+			r.Spec.Zones = []string{"us-west-2b"}
+		}},
+		{"map keys", func(r *patchResource) {
+			r.Spec.Tags["env"] = nil
+			r.Spec.Tags["owner"] = strP("ack")
+		}},
+	}
+
+	for _, tc := range testCases {
+		a := base()
+		b := base()
+		tc.modify(&b)
+
+		delta, err := compare.Diff(a, b)
+		require.NoError(t, err)
+		patch, err := delta.MergePatch()
+		require.NoError(t, err, fmt.Sprintf("for %s", tc.name))
+
+		aJSON, err := json.Marshal(a)
+		require.NoError(t, err)
+		var doc, patchDoc interface{}
+		require.NoError(t, json.Unmarshal(aJSON, &doc))
+		require.NoError(t, json.Unmarshal(patch, &patchDoc))
+		patched, err := json.Marshal(applyMergePatch(doc, patchDoc))
+		require.NoError(t, err)
+
+		var got patchResource
+		require.NoError(t, json.Unmarshal(patched, &got))
+		if tc.name == "map keys" {
+			// A null map value cannot be distinguished from a removed key
+			delete(b.Spec.Tags, "env")
+		}
+		assert.Equal(t, b, got, fmt.Sprintf("for %s with patch %s", tc.name, patch))
+	}
+}
+
+func TestDelta_MergePatch(t *testing.T) {
+	require := require.New(t)
+
+	delta := compare.NewDelta()
+	delta.Add("Spec.DBInstanceIdentifier", strP("db-1"), strP("db-2"))
+	delta.Add("Spec.Config.Name", nil, strP("cfg"))
+	delta.Add("Spec.AllocatedStorage", int64P(20), nil)
+	patch, err := delta.MergePatch()
+	require.NoError(err)
+	require.JSONEq(`{"spec": {
+		"dbInstanceIdentifier": "db-2",
+		"config": {"name": "cfg"},
+		"allocatedStorage": null
+	}}`, string(patch))
+
+	patch, err = compare.NewDelta().MergePatch()
+	require.NoError(err)
+	require.JSONEq(`{}`, string(patch))
+
+	// List elements cannot be addressed by a merge patch
+	delta = compare.NewDelta()
+	delta.Add("Spec.Rules[0].Port", int64P(80), int64P(443))
+	_, err = delta.MergePatch()
+	require.Error(err)
+
+	// Overlapping differences are ambiguous
+	delta = compare.NewDelta()
+	delta.Add("Spec.Config", nil, &patchConfig{})
+	delta.Add("Spec.Config.Name", nil, strP("cfg"))
+	_, err = delta.MergePatch()
+	require.Error(err)
+
+	delta = compare.NewDelta()
+	delta.Add("Spec.Config.Name", nil, strP("cfg"))
+	delta.Add("Spec.Config", nil, &patchConfig{})
+	_, err = delta.MergePatch()
+	require.Error(err)
+}
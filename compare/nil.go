@@ -38,7 +38,7 @@ func IsNil(i interface{}) bool {
 	}
 
 	switch reflect.TypeOf(i).Kind() {
-	case reflect.Ptr, reflect.Map, reflect.Array, reflect.Chan, reflect.Slice:
+	case reflect.Ptr, reflect.Map, reflect.Chan, reflect.Slice:
 		return reflect.ValueOf(i).IsNil()
 	}
 	return false
@@ -48,3 +48,40 @@ func IsNil(i interface{}) bool {
 func IsNotNil(i interface{}) bool {
 	return !IsNil(i)
 }
+
+// PEqual returns true if the supplied pointers are both nil, or both point to
+// equal values. Nil pointers are never dereferenced, and a nil pointer is not
+// equal to a pointer to the zero value unless the NilEqualsZero Option is
+// supplied:
+//
+//	a      b      PEqual  PEqual with NilEqualsZero
+//	nil    nil    true    true
+//	nil    &""    false   true
+//	nil    &"a"   false   false
+//	&""    &""    true    true
+//	&"a"   &"b"   false   false
+//
+// Options other than NilEqualsZero are ignored.
+func PEqual[T comparable](a, b *T, opts ...Option) bool {
+	if a == nil || b == nil {
+		if a == nil && b == nil {
+			return true
+		}
+		if !newOptions(opts).nilEqualsZero {
+			return false
+		}
+		var zero T
+		return derefOrZero(a) == zero && derefOrZero(b) == zero
+	}
+	return *a == *b
+}
+
+// derefOrZero returns the value the supplied pointer points to, or the zero
+// value if the pointer is nil
+func derefOrZero[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
@@ -1,6 +1,7 @@
 package compare_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -43,3 +44,96 @@ func TestNilDifference(t *testing.T) {
 	require.True(compare.HasNilDifference(nullChan, nonNullChan))
 
 }
+
+func TestPEqual(t *testing.T) {
+	require := require.New(t)
+
+	require.True(compare.PEqual(int64P(1), int64P(1)))
+	require.False(compare.PEqual(int64P(1), int64P(2)))
+	require.True(compare.PEqual(strP("a"), strP("a")))
+	require.False(compare.PEqual(strP("a"), strP("b")))
+	require.True(compare.PEqual[int64](nil, int64P(0), compare.NilEqualsZero()))
+	require.False(compare.PEqual[int64](nil, int64P(1), compare.NilEqualsZero()))
+	require.True(compare.PEqual(boolP(false), nil, compare.NilEqualsZero()))
+}
+
+// TestPointerHelpers_Nil checks every combination of nil, empty and non-empty
+// values against each of the pointer-based comparison helpers
+func TestPointerHelpers_Nil(t *testing.T) {
+	require := require.New(t)
+
+	testCases := []struct {
+		a, b          *string
+		expect        bool
+		nilEqualsZero bool
+	}{
+		{nil, nil, true, true},
+		{nil, strP(""), false, true},
+		{strP(""), nil, false, true},
+		{nil, strP("a"), false, false},
+		{strP("a"), nil, false, false},
+		{strP(""), strP(""), true, true},
+		{strP(""), strP("a"), false, false},
+		{strP("a"), strP("a"), true, true},
+		{strP("a"), strP("b"), false, false},
+	}
+
+	for _, tc := range testCases {
+		for _, nilEqualsZero := range []bool{false, true} {
+			opts := []compare.Option{}
+			expect := tc.expect
+			if nilEqualsZero {
+				opts = append(opts, compare.NilEqualsZero())
+				expect = tc.nilEqualsZero
+			}
+			msg := fmt.Sprintf(
+				"for a %s, b %s with NilEqualsZero %t",
+				describeP(tc.a), describeP(tc.b), nilEqualsZero,
+			)
+
+			require.Equal(expect, compare.PEqual(tc.a, tc.b, opts...), msg)
+			require.Equal(expect, compare.SlicePEqualOrdered(
+				[]*string{strP("x"), tc.a}, []*string{strP("x"), tc.b}, opts...,
+			), msg)
+			require.Equal(expect, compare.SlicePEqualUnordered(
+				[]*string{tc.a, strP("x")}, []*string{strP("x"), tc.b}, opts...,
+			), msg)
+			require.Equal(expect, compare.MapPEqual(
+				map[string]*string{"k": tc.a}, map[string]*string{"k": tc.b}, opts...,
+			), msg)
+			require.Equal(expect, compare.SliceStringPEqualWithOptions(
+				[]*string{tc.a, strP("x")}, []*string{strP("x"), tc.b}, opts...,
+			), msg)
+			require.Equal(expect, compare.MapStringStringPEqualWithOptions(
+				map[string]*string{"k": tc.a}, map[string]*string{"k": tc.b}, opts...,
+			), msg)
+
+			delta, err := compare.Diff(
+				testResource{Spec: testSpec{Engine: tc.a}},
+				testResource{Spec: testSpec{Engine: tc.b}},
+				opts...,
+			)
+			require.NoError(err)
+			require.Equal(expect, len(delta.Differences) == 0, msg)
+		}
+	}
+
+	// Nil elements without counterparts
+	require.False(compare.SlicePEqualUnordered(
+		[]*string{nil, strP("a")}, []*string{strP("a"), strP("a")},
+	))
+	require.True(compare.SlicePEqualUnordered(
+		[]*string{nil, strP("a")}, []*string{strP("a"), strP("")},
+		compare.NilEqualsZero(),
+	))
+	require.True(compare.SlicePEqualUnordered(
+		[]*string{nil, nil, strP("a")}, []*string{strP("a"), nil, nil},
+	))
+}
+
+func describeP(s *string) string {
+	if s == nil {
+		return "nil"
+	}
+	return fmt.Sprintf("%q", *s)
+}
@@ -0,0 +1,206 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare
+
+import "reflect"
+
+// Option configures the behaviour of Diff and of the comparison helper
+// functions that accept Options, e.g. SliceStringEqualWithOptions and
+// MapStringStringPEqualWithOptions. The helper functions always treat nil and empty
+// values as equal, and match Paths relative to the values they compare, e.g.
+// IgnorePaths("env") ignores the "env" key of the compared maps.
+type Option func(*options)
+
+// options contains the configuration assembled from the Options passed to
+// Diff
+type options struct {
+	// prefix contains the Path parts prepended to the Path of every
+	// Difference
+	prefix []pathPart
+	// ignore contains the patterns of Paths at and beneath which differences
+	// are ignored
+	ignore []pathPattern
+	// equateEmpty is true if nil and empty slices and maps are equal
+	equateEmpty bool
+	// ignoreUnsetInDesired is true if differences where the first value is
+	// unset are ignored
+	ignoreUnsetInDesired bool
+	// nilEqualsZero is true if a nil pointer is equal to a pointer to the
+	// zero value
+	nilEqualsZero bool
+	// ignoreCase contains the patterns of Paths at and beneath which strings
+	// are compared case-insensitively
+	ignoreCase []pathPattern
+	// unordered contains the patterns of Paths at and beneath which lists are
+	// compared regardless of order
+	unordered []pathPattern
+	// documents contains the patterns of Paths of strings holding JSON or
+	// YAML documents
+	documents []pathPattern
+	// sensitive contains the patterns of Paths whose Differences are
+	// sensitive
+	sensitive []pathPattern
+	// sensitiveTypes contains the types whose values are sensitive
+	sensitiveTypes map[reflect.Type]bool
+	// keyed contains the lists compared as sets of keyed elements
+	keyed []keyedSlice
+	// comparers contains the registries of Comparers supplied using
+	// WithComparers
+	comparers []*Comparers
+}
+
+// newOptions returns the options assembled from the supplied Options
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithPathPrefix prepends the supplied dotted-notation path to the Path of
+// every Difference returned by Diff. This is useful when comparing a part of
+// a resource, e.g.:
+//
+//	delta, err := compare.Diff(
+//	    a.ko.Spec, b.ko.Spec, compare.WithPathPrefix("Spec"),
+//	)
+//
+// produces Differences with Paths such as "Spec.AllocatedStorage".
+func WithPathPrefix(prefix string) Option {
+	return func(o *options) {
+		if prefix == "" {
+			return
+		}
+		o.prefix = nil
+		for _, part := range splitPath(prefix) {
+			o.prefix = append(o.prefix, pathPart{part, jsonName(part)})
+		}
+	}
+}
+
+// IgnorePaths ignores any difference at or beneath the supplied
+// dotted-notation paths. A "*" part matches any single field name or map key
+// and a "[*]" part matches any list element, e.g.:
+//
+//	// Tags are synced separately and Status is not user-controlled
+//	compare.IgnorePaths("Spec.Tags", "Status")
+//	// Ignore the server-assigned IDs of EC2 SecurityGroup rules
+//	compare.IgnorePaths("Spec.IngressRules[*].IPRanges[*].RuleID")
+//	// Ignore the value of every Lambda Function environment variable
+//	compare.IgnorePaths("Spec.Environment.Variables.*")
+//
+// Paths include any prefix added using WithPathPrefix.
+func IgnorePaths(paths ...string) Option {
+	return func(o *options) {
+		o.ignore = append(o.ignore, newPathPatterns(paths)...)
+	}
+}
+
+// EquateEmpty treats a nil slice or map as equal to an empty one. Without it,
+// a nil value differs from a non-nil value, as HasNilDifference reports. For
+// example, an RDS DBInstance whose Spec.VPCSecurityGroupIDs is unset in the
+// manifest is not different from one for which the API returned an empty
+// list.
+func EquateEmpty() Option {
+	return func(o *options) {
+		o.equateEmpty = true
+	}
+}
+
+// IgnoreUnsetInDesired ignores differences where the first value under
+// comparison, which is expected to be the desired state, is unset: a nil
+// pointer, interface, slice or map, a missing map key or any other zero
+// value. Values set only in the latest state, typically server-side defaults
+// such as an RDS DBInstance's Spec.AvailabilityZone or an ElastiCache
+// ReplicationGroup's Spec.PreferredMaintenanceWindow, are then not reported
+// as differences.
+//
+// Pointers to zero values, e.g. a pointer to "", are considered set.
+func IgnoreUnsetInDesired() Option {
+	return func(o *options) {
+		o.ignoreUnsetInDesired = true
+	}
+}
+
+// NilEqualsZero treats a nil pointer as equal to a pointer to the zero value
+// of its type, e.g. a nil *string as equal to a pointer to "". Without it, a
+// nil pointer is only equal to another nil pointer. This is useful for fields
+// that AWS APIs omit from responses when they are empty, such as an EC2
+// SecurityGroup rule's Description.
+//
+// NilEqualsZero is also honoured by the generic pointer comparison helpers,
+// e.g. PEqual and SlicePEqualUnordered, which ignore any other Options.
+func NilEqualsZero() Option {
+	return func(o *options) {
+		o.nilEqualsZero = true
+	}
+}
+
+// IgnoreCase compares strings at or beneath the supplied dotted-notation paths
+// case-insensitively, using the same pattern syntax as IgnorePaths. If no
+// paths are supplied, all strings are compared case-insensitively. For
+// example, AWS lowercases RDS DBInstance identifiers:
+//
+//	compare.IgnoreCase("Spec.DBInstanceIdentifier")
+func IgnoreCase(paths ...string) Option {
+	return func(o *options) {
+		if len(paths) == 0 {
+			paths = []string{""}
+		}
+		o.ignoreCase = append(o.ignoreCase, newPathPatterns(paths)...)
+	}
+}
+
+// UnorderedLists compares lists at or beneath the supplied dotted-notation
+// paths regardless of the order of their elements, using the same pattern
+// syntax as IgnorePaths. If no paths are supplied, all lists are compared
+// regardless of order. Lists of scalar values are always compared regardless
+// of order. Lists whose elements differ are recorded as a single Difference
+// for the whole list, since elements cannot be paired by index.
+func UnorderedLists(paths ...string) Option {
+	return func(o *options) {
+		if len(paths) == 0 {
+			paths = []string{""}
+		}
+		o.unordered = append(o.unordered, newPathPatterns(paths)...)
+	}
+}
+
+// EmbeddedDocuments compares the strings (or pointers to strings) at the
+// supplied dotted-notation paths as JSON or YAML documents, as YAMLStringEqual
+// does, using the same pattern syntax as IgnorePaths. Differences within the
+// documents are recorded beneath the string's Path, e.g.
+// "Spec.Definition.States.Foo.Type", with the parsed values as their A and B.
+// If either string is not a valid document, the strings are compared as is.
+//
+// Differences recorded within documents are marked as Embedded. They cannot be
+// applied by ApplyTo, JSONPatch or MergePatch, which return an error, since
+// the field itself holds a string. For example, Step Functions state machine
+// definitions:
+//
+//	compare.EmbeddedDocuments("Spec.Definition")
+func EmbeddedDocuments(paths ...string) Option {
+	return func(o *options) {
+		o.documents = append(o.documents, newPathPatterns(paths)...)
+	}
+}
+
+// equalWithOptions returns true if Diff finds no differences between the
+// supplied values with the supplied Options. It is used by the comparison
+// helper functions, which always treat nil and empty values as equal.
+func equalWithOptions(a, b any, opts []Option) bool {
+	delta, err := Diff(a, b, append([]Option{EquateEmpty()}, opts...)...)
+	return err == nil && len(delta.Differences) == 0
+}
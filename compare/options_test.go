@@ -0,0 +1,336 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/pkg/compare"
+)
+
+func TestDiff_IgnorePaths(t *testing.T) {
+	require := require.New(t)
+
+	a := testResource{Spec: testSpec{
+		AllocatedStorage: int64P(20),
+		Engine:           strP("postgres"),
+		Config:           &testConfig{Name: strP("a"), Enabled: boolP(true)},
+		Rules: []*testRule{
+			{Port: int64P(80), Protocol: strP("tcp")},
+			{Port: int64P(443), Protocol: strP("tcp")},
+		},
+		Labels: map[string]*string{"env": strP("dev"), "team": strP("a")},
+	}}
+	b := testResource{Spec: testSpec{
+		AllocatedStorage: int64P(40),
+		Engine:           strP("mysql"),
+		Config:           &testConfig{Name: strP("b"), Enabled: boolP(false)},
+		Rules: []*testRule{
+			{Port: int64P(8080), Protocol: strP("udp")},
+			{Port: int64P(8443), Protocol: strP("tcp")},
+		},
+		Labels: map[string]*string{"env": strP("prod"), "owner": strP("b")},
+	}}
+
+	testCases := []struct {
+		ignore []string
+		expect []string
+	}{
+		{
+			nil,
+			[]string{
+				"Spec.AllocatedStorage", "Spec.Engine", "Spec.Config.Name",
+				"Spec.Config.Enabled", "Spec.Rules[0].Port",
+				"Spec.Rules[0].Protocol", "Spec.Rules[1].Port",
+				"Spec.Labels.env", "Spec.Labels.owner", "Spec.Labels.team",
+			},
+		},
+		{
+			[]string{"Spec.Config", "Spec.Labels", "Spec.Rules"},
+			[]string{"Spec.AllocatedStorage", "Spec.Engine"},
+		},
+		{
+			[]string{"Spec.Rules[*].Port", "Spec.Labels.*", "Spec.*.Name"},
+			[]string{
+				"Spec.AllocatedStorage", "Spec.Engine", "Spec.Config.Enabled",
+				"Spec.Rules[0].Protocol",
+			},
+		},
+		{
+			[]string{"Spec.Rules[0]", "Spec.Labels.team", "Spec.Engine"},
+			[]string{
+				"Spec.AllocatedStorage", "Spec.Config.Name",
+				"Spec.Config.Enabled", "Spec.Rules[1].Port",
+				"Spec.Labels.env", "Spec.Labels.owner",
+			},
+		},
+		{
+			[]string{"Spec"},
+			[]string{},
+		},
+	}
+	for _, tc := range testCases {
+		delta, err := compare.Diff(a, b, compare.IgnorePaths(tc.ignore...))
+		require.NoError(err)
+		paths := []string{}
+		for _, diff := range delta.Differences {
+			paths = append(paths, diff.Path.String())
+		}
+		require.Equal(tc.expect, paths, tc.ignore)
+	}
+
+	delta, err := compare.Diff(
+		a.Spec, b.Spec,
+		compare.WithPathPrefix("Spec"),
+		compare.IgnorePaths("Spec.Rules", "Spec.Labels", "Spec.Config"),
+	)
+	require.NoError(err)
+	require.Len(delta.Differences, 2)
+}
+
+func TestDiff_EquateEmpty(t *testing.T) {
+	require := require.New(t)
+
+	a := testResource{}
+	b := testResource{Spec: testSpec{
+		AvailabilityZones: []string{},
+		Rules:             []*testRule{},
+		Labels:            map[string]*string{},
+	}}
+
+	delta, err := compare.Diff(a, b)
+	require.NoError(err)
+	require.Len(delta.Differences, 3)
+
+	delta, err = compare.Diff(a, b, compare.EquateEmpty())
+	require.NoError(err)
+	require.Empty(delta.Differences)
+
+	b.Spec.AvailabilityZones = []string{"us-west-2a"}
+	delta, err = compare.Diff(a, b, compare.EquateEmpty())
+	require.NoError(err)
+	require.Len(delta.Differences, 1)
+	require.True(delta.DifferentAt("Spec.AvailabilityZones"))
+}
+
+func TestDiff_IgnoreUnsetInDesired(t *testing.T) {
+	require := require.New(t)
+
+	desired := testResource{Spec: testSpec{
+		Engine: strP("postgres"),
+		Config: &testConfig{Name: strP("cfg")},
+		Labels: map[string]*string{"env": strP("dev")},
+	}}
+	latest := testResource{Spec: testSpec{
+		AllocatedStorage:  int64P(20),
+		Engine:            strP("postgres"),
+		AvailabilityZones: []string{"us-west-2a"},
+		Config:            &testConfig{Name: strP("cfg"), Enabled: boolP(true)},
+		Labels:            map[string]*string{"env": strP("dev"), "owner": strP("b")},
+	}}
+
+	delta, err := compare.Diff(desired, latest)
+	require.NoError(err)
+	require.Len(delta.Differences, 4)
+
+	delta, err = compare.Diff(desired, latest, compare.IgnoreUnsetInDesired())
+	require.NoError(err)
+	require.Empty(delta.Differences)
+
+	// Values set in desired are still compared, including empty strings
+	desired.Spec.Engine = strP("")
+	desired.Spec.Labels["owner"] = strP("a")
+	delta, err = compare.Diff(desired, latest, compare.IgnoreUnsetInDesired())
+	require.NoError(err)
+	require.Len(delta.Differences, 2)
+	require.True(delta.DifferentAt("Spec.Engine"))
+	require.True(delta.DifferentAt("Spec.Labels.owner"))
+
+	// Values unset only in latest are still differences
+	delta, err = compare.Diff(latest, desired, compare.IgnoreUnsetInDesired())
+	require.NoError(err)
+	require.True(delta.DifferentAt("Spec.AllocatedStorage"))
+}
+
+func TestDiff_IgnoreCase(t *testing.T) {
+	require := require.New(t)
+
+	a := testResource{Spec: testSpec{
+		Engine:            strP("Postgres"),
+		AvailabilityZones: []string{"US-WEST-2A"},
+		Config:            &testConfig{Name: strP("MyConfig")},
+	}}
+	b := testResource{Spec: testSpec{
+		Engine:            strP("postgres"),
+		AvailabilityZones: []string{"us-west-2a"},
+		Config:            &testConfig{Name: strP("myconfig")},
+	}}
+
+	delta, err := compare.Diff(a, b)
+	require.NoError(err)
+	require.Len(delta.Differences, 3)
+
+	delta, err = compare.Diff(a, b, compare.IgnoreCase("Spec.Engine", "Spec.Config"))
+	require.NoError(err)
+	require.Len(delta.Differences, 1)
+	require.True(delta.DifferentAt("Spec.AvailabilityZones"))
+
+	delta, err = compare.Diff(a, b, compare.IgnoreCase())
+	require.NoError(err)
+	require.Empty(delta.Differences)
+
+	b.Spec.Engine = strP("mysql")
+	delta, err = compare.Diff(a, b, compare.IgnoreCase())
+	require.NoError(err)
+	require.Len(delta.Differences, 1)
+}
+
+func TestDiff_UnorderedLists(t *testing.T) {
+	require := require.New(t)
+
+	a := testResource{Spec: testSpec{
+		Rules: []*testRule{
+			{Port: int64P(80), Protocol: strP("tcp")},
+			{Port: int64P(53), Protocol: strP("udp")},
+		},
+	}}
+	b := testResource{Spec: testSpec{
+		Rules: []*testRule{
+			{Port: int64P(53), Protocol: strP("udp")},
+			{Port: int64P(80), Protocol: strP("tcp")},
+		},
+	}}
+
+	delta, err := compare.Diff(a, b)
+	require.NoError(err)
+	require.Len(delta.Differences, 4)
+
+	delta, err = compare.Diff(a, b, compare.UnorderedLists("Spec.Rules"))
+	require.NoError(err)
+	require.Empty(delta.Differences)
+
+	delta, err = compare.Diff(a, b, compare.UnorderedLists())
+	require.NoError(err)
+	require.Empty(delta.Differences)
+
+	b.Spec.Rules[0].Port = int64P(54)
+	delta, err = compare.Diff(a, b, compare.UnorderedLists("Spec.Rules"))
+	require.NoError(err)
+	require.Len(delta.Differences, 1)
+	require.Equal("Spec.Rules", delta.Differences[0].Path.String())
+}
+
+func TestDiff_EmbeddedDocuments(t *testing.T) {
+	require := require.New(t)
+
+	a := testResource{Spec: testSpec{
+		Engine: strP(`{"States": {"Foo": {"Type": "Pass", "End": true}}}`),
+	}}
+	b := testResource{Spec: testSpec{
+		Engine: strP("States:\n  Foo:\n    End: true\n    Type: Pass\n"),
+	}}
+
+	delta, err := compare.Diff(a, b)
+	require.NoError(err)
+	require.Len(delta.Differences, 1)
+
+	delta, err = compare.Diff(a, b, compare.EmbeddedDocuments("Spec.Engine"))
+	require.NoError(err)
+	require.Empty(delta.Differences)
+
+	b.Spec.Engine = strP("States:\n  Foo:\n    End: true\n    Type: Task\n")
+	delta, err = compare.Diff(a, b, compare.EmbeddedDocuments("Spec.Engine"))
+	require.NoError(err)
+	require.Len(delta.Differences, 1)
+	diff := delta.Differences[0]
+	require.Equal("Spec.Engine.States.Foo.Type", diff.Path.String())
+	require.Equal("Pass", diff.A)
+	require.Equal("Task", diff.B)
+	require.True(diff.Embedded)
+
+	// Differences within documents cannot be applied to the resource
+	_, err = delta.JSONPatch()
+	require.Error(err)
+	_, err = delta.MergePatch()
+	require.Error(err)
+	target := a
+	err = delta.ApplyTo(&target, compare.SideB)
+	require.IsType(&compare.UnreachablePathError{}, err)
+	require.Equal(a, target)
+
+	encoded, err := json.Marshal(delta)
+	require.NoError(err)
+	decoded := compare.NewDelta()
+	require.NoError(json.Unmarshal(encoded, decoded))
+	require.True(decoded.Differences[0].Embedded)
+
+	// Strings that are not valid documents are compared as is
+	b.Spec.Engine = strP("{")
+	delta, err = compare.Diff(a, b, compare.EmbeddedDocuments("Spec.Engine"))
+	require.NoError(err)
+	require.Len(delta.Differences, 1)
+	require.Equal("Spec.Engine", delta.Differences[0].Path.String())
+	require.False(delta.Differences[0].Embedded)
+
+	// Scalar documents
+	a.Spec.Engine = strP("postgres")
+	b.Spec.Engine = strP("'postgres'")
+	delta, err = compare.Diff(a, b, compare.EmbeddedDocuments("Spec.Engine"))
+	require.NoError(err)
+	require.Empty(delta.Differences)
+
+	b.Spec.Engine = nil
+	delta, err = compare.Diff(a, b, compare.EmbeddedDocuments("Spec.Engine"))
+	require.NoError(err)
+	require.Len(delta.Differences, 1)
+}
+
+func TestHelpers_Options(t *testing.T) {
+	require := require.New(t)
+
+	// The helpers without Options keep their original function types
+	var _ func(a, b []string) bool = compare.SliceStringEqual
+	var _ func(a, b []*string) bool = compare.SliceStringPEqual
+	var _ func(a, b map[string]string) bool = compare.MapStringStringEqual
+	var _ func(a, b map[string]*string) bool = compare.MapStringStringPEqual
+
+	require.False(compare.SliceStringEqualWithOptions([]string{"A"}, []string{"a"}))
+	require.True(compare.SliceStringEqualWithOptions(
+		[]string{"A", "b"}, []string{"B", "a"}, compare.IgnoreCase(),
+	))
+	require.True(compare.SliceStringPEqualWithOptions(
+		[]*string{strP("A")}, []*string{strP("a")}, compare.IgnoreCase(),
+	))
+	require.True(compare.SliceStringEqualWithOptions(
+		nil, []string{}, compare.IgnoreCase(),
+	))
+
+	a := map[string]string{"env": "dev", "owner": "a"}
+	b := map[string]string{"env": "dev", "owner": "b"}
+	require.False(compare.MapStringStringEqualWithOptions(a, b))
+	require.True(compare.MapStringStringEqualWithOptions(
+		a, b, compare.IgnorePaths("owner"),
+	))
+	require.True(compare.MapStringStringEqualWithOptions(
+		map[string]string{"env": "dev"}, b, compare.IgnoreUnsetInDesired(),
+	))
+	require.True(compare.MapStringStringPEqualWithOptions(
+		map[string]*string{"env": strP("DEV")},
+		map[string]*string{"env": strP("dev")},
+		compare.IgnoreCase("env"),
+	))
+}
@@ -15,16 +15,70 @@ package compare
 
 import (
 	"encoding/json"
+	"fmt"
+	"strconv"
 	"strings"
+
+	"github.com/aws-controllers-k8s/pkg/path/fieldpath"
 )
 
 // Path provides a JSONPath-like struct and field-member "route" to a
 // particular field within a compared struct. Path implements json.Marshaler
 // interface.
+//
+// Each part of a Path is either the name of a struct field or map key, e.g.
+// "Spec" or "AllocatedStorage", or a bracketed slice element selector, e.g.
+// "[0]", or "[Key=env]" for lists compared using KeyedSlice. In dotted
+// notation, element selectors are attached to the part that precedes them,
+// e.g. "Spec.Rules[0].Port".
+//
+// A Path can be converted to and from a fieldpath.Path using FieldPath and
+// FromFieldPath.
 type Path struct {
 	parts []string
 }
 
+// String returns the dotted-notation representation of the Path, e.g.
+// "Spec.Rules[0].Port". Parts containing ".", "[" or "]", e.g. the map key
+// "kubernetes.io/cluster/x", are double-quoted, with any double quote or
+// backslash within them escaped by a backslash, e.g.
+// `Spec.Tags."kubernetes.io/cluster/x"`, so that NewPath returns the same
+// Path for the string.
+func (p Path) String() string {
+	var b strings.Builder
+	for x, part := range p.parts {
+		if x > 0 && !isElementPart(part) {
+			b.WriteByte('.')
+		}
+		if needsQuoting(part) {
+			writeQuoted(&b, part)
+			continue
+		}
+		b.WriteString(part)
+	}
+	return b.String()
+}
+
+// needsQuoting returns true if the supplied Path part must be double-quoted
+// in dotted notation
+func needsQuoting(part string) bool {
+	return !isElementPart(part) &&
+		(strings.ContainsAny(part, ".[]") || strings.HasPrefix(part, `"`))
+}
+
+// writeQuoted writes the supplied Path part to the supplied builder, enclosed
+// in double quotes and with any double quote or backslash escaped
+func writeQuoted(b *strings.Builder, part string) {
+	b.WriteByte('"')
+	for _, r := range part {
+		if r == '"' || r == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	b.WriteByte('"')
+}
+
 // MarshalJSON returns the JSON encoding of a Path object.
 func (p Path) MarshalJSON() ([]byte, error) {
 	// Since json.Marshall doesn't encode unexported struct fields we have to
@@ -39,16 +93,96 @@ func (p Path) MarshalJSON() ([]byte, error) {
 	)
 }
 
-// Push adds a new part to the Path.
-func (p Path) Push(part string) {
-	p.parts = append(p.parts, part)
+// UnmarshalJSON decodes a Path from either the object encoding returned by
+// MarshalJSON, e.g. {"Parts":["Spec","Rules","[0]","Port"]}, or a
+// dotted-notation string, e.g. "Spec.Rules[0].Port".
+func (p *Path) UnmarshalJSON(data []byte) error {
+	var dotted string
+	if err := json.Unmarshal(data, &dotted); err == nil {
+		*p = NewPath(dotted)
+		return nil
+	}
+	var obj struct {
+		Parts []string
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return fmt.Errorf(
+			"path must be a dotted-notation string or an object with Parts: %v",
+			err,
+		)
+	}
+	*p = Path{obj.Parts}
+	return nil
+}
+
+// Push adds a new part to the end of the Path.
+func (p *Path) Push(part string) {
+	// Paths are copied by value, e.g. into each Difference, so the copies may
+	// share the backing array of p.parts. Appending to a full-length slice
+	// always allocates, ensuring those copies are never modified.
+	p.parts = append(p.parts[:len(p.parts):len(p.parts)], part)
 }
 
-// Pop removes the last part from the Path
-func (p Path) Pop() {
+// Pop removes the last part from the Path and returns it. If the Path has no
+// parts, Pop returns the empty string.
+func (p *Path) Pop() (part string) {
 	if len(p.parts) > 0 {
+		part = p.parts[len(p.parts)-1]
 		p.parts = p.parts[:len(p.parts)-1]
 	}
+	return part
+}
+
+// Parts returns a copy of the parts of the Path
+func (p Path) Parts() []string {
+	return append([]string{}, p.parts...)
+}
+
+// At returns the part of the Path at the supplied index, or the empty string
+// if the index is out of range
+func (p Path) At(index int) string {
+	if index < 0 || index >= len(p.parts) {
+		return ""
+	}
+	return p.parts[index]
+}
+
+// Front returns the first part of the Path, or the empty string if the Path
+// has no parts
+func (p Path) Front() string {
+	return p.At(0)
+}
+
+// Back returns the last part of the Path, or the empty string if the Path has
+// no parts
+func (p Path) Back() string {
+	return p.At(len(p.parts) - 1)
+}
+
+// Empty returns true if there are no parts to the Path
+func (p Path) Empty() bool {
+	return len(p.parts) == 0
+}
+
+// Size returns the Path number of parts
+func (p Path) Size() int {
+	return len(p.parts)
+}
+
+// FieldPath returns the Path as a *fieldpath.Path. Element selectors are
+// attached to the part that precedes them, so "Spec.Rules[0].Port" becomes a
+// fieldpath.Path with the parts "Spec", "Rules[0]" and "Port", the same as
+// fieldpath.FromString would return for that string.
+func (p Path) FieldPath() *fieldpath.Path {
+	parts := []string{}
+	for _, part := range p.parts {
+		if isElementPart(part) && len(parts) > 0 {
+			parts[len(parts)-1] += part
+			continue
+		}
+		parts = append(parts, part)
+	}
+	return fieldpath.FromParts(parts...)
 }
 
 // Contains returns true if the supplied string, delimited on ".", matches
@@ -60,7 +194,7 @@ func (p Path) Pop() {
 //			subject "B" -> false
 //			subject "A.C" -> false
 func (p Path) Contains(subject string) bool {
-	subjectSplit := strings.Split(subject, ".")
+	subjectSplit := splitPath(subject)
 
 	if len(subjectSplit) > len(p.parts) {
 		return false
@@ -75,8 +209,156 @@ func (p Path) Contains(subject string) bool {
 	return true
 }
 
+// overlaps returns true if either Path is a prefix of the other. The root
+// Path overlaps every Path.
+func (p Path) overlaps(other Path) bool {
+	if p.isRoot() || other.isRoot() {
+		return true
+	}
+	n := len(p.parts)
+	if len(other.parts) < n {
+		n = len(other.parts)
+	}
+	for x := 0; x < n; x++ {
+		if p.parts[x] != other.parts[x] {
+			return false
+		}
+	}
+	return true
+}
+
+// isRoot returns true if the Path refers to the root of the compared values
+func (p Path) isRoot() bool {
+	return len(p.parts) == 0 || (len(p.parts) == 1 && p.parts[0] == "")
+}
+
 // NewPath returns a new Path struct pointer from a dotted-notation string,
-// e.g. "Author.Name"
+// e.g. "Author.Name" or "Spec.Rules[0].Port"
 func NewPath(dotted string) Path {
-	return Path{strings.Split(dotted, ".")}
+	return Path{splitPath(dotted)}
+}
+
+// FromFieldPath returns a new Path from the supplied *fieldpath.Path. Element
+// selectors within its parts, e.g. "Rules[0]", become parts of their own. A
+// nil fieldpath.Path results in a Path with no parts.
+func FromFieldPath(fp *fieldpath.Path) Path {
+	if fp == nil {
+		return Path{}
+	}
+	parts := []string{}
+	for _, part := range fp.Parts() {
+		parts = append(parts, splitElements(part)...)
+	}
+	return Path{parts}
+}
+
+// isElementPart returns true if the supplied Path part is a bracketed slice
+// element selector, e.g. "[0]"
+func isElementPart(part string) bool {
+	return strings.HasPrefix(part, "[") && strings.HasSuffix(part, "]")
+}
+
+// isIndexPart returns true if the supplied Path part selects a slice element by
+// index, e.g. "[0]", rather than by key, e.g. "[Key=env]"
+func isIndexPart(part string) bool {
+	if !isElementPart(part) {
+		return false
+	}
+	_, err := strconv.Atoi(part[1 : len(part)-1])
+	return err == nil
+}
+
+// splitElements splits the bracketed element selectors from a single Path
+// part, e.g. "Rules[0]" is split into "Rules" and "[0]". Unlike splitPath, "."
+// does not delimit parts, so map keys containing dots are preserved.
+func splitElements(part string) []string {
+	open := strings.IndexByte(part, '[')
+	if open < 0 || !strings.HasSuffix(part, "]") {
+		return []string{part}
+	}
+	parts := []string{}
+	if open > 0 {
+		parts = append(parts, part[:open])
+	}
+	for rest := part[open:]; rest != ""; {
+		end := strings.IndexByte(rest, ']')
+		if !strings.HasPrefix(rest, "[") || end < 0 {
+			// Not a well-formed sequence of selectors, keep the part whole
+			return []string{part}
+		}
+		parts = append(parts, rest[:end+1])
+		rest = rest[end+1:]
+	}
+	return parts
+}
+
+// splitPath splits a dotted-notation string into Path parts. Parts are
+// delimited by "." and bracketed element selectors become parts of their own,
+// so "Spec.Rules[0].Port" is split into "Spec", "Rules", "[0]" and "Port".
+// Dots within brackets do not delimit parts. A part starting with a double
+// quote extends to the closing double quote, and a backslash within it
+// escapes the following character, so `Tags."a.b"` is split into "Tags" and
+// "a.b".
+func splitPath(dotted string) []string {
+	parts := []string{}
+	var cur strings.Builder
+	inBracket := false
+	afterBracket := false
+	inQuote := false
+	escaped := false
+	// partStart is true at the start of each part, where a double quote may
+	// open a quoted part
+	partStart := true
+	for _, r := range dotted {
+		switch {
+		case inQuote:
+			switch {
+			case escaped:
+				cur.WriteRune(r)
+				escaped = false
+			case r == '\\':
+				escaped = true
+			case r == '"':
+				inQuote = false
+			default:
+				cur.WriteRune(r)
+			}
+			continue
+		case inBracket:
+			cur.WriteRune(r)
+			if r == ']' {
+				parts = append(parts, cur.String())
+				cur.Reset()
+				inBracket = false
+				afterBracket = true
+				partStart = true
+			}
+			continue
+		case r == '"' && partStart:
+			inQuote = true
+		case r == '.':
+			if !afterBracket {
+				parts = append(parts, cur.String())
+				cur.Reset()
+			}
+			partStart = true
+			afterBracket = false
+			continue
+		case r == '[':
+			if cur.Len() > 0 {
+				parts = append(parts, cur.String())
+				cur.Reset()
+			}
+			cur.WriteRune(r)
+			inBracket = true
+		default:
+			cur.WriteRune(r)
+		}
+		afterBracket = false
+		partStart = false
+	}
+	if !afterBracket || cur.Len() > 0 {
+		parts = append(parts, cur.String())
+	}
+	return parts
 }
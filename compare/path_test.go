@@ -0,0 +1,176 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare_test
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/pkg/compare"
+	"github.com/aws-controllers-k8s/pkg/path/fieldpath"
+)
+
+func TestPath_String(t *testing.T) {
+	require := require.New(t)
+
+	testCases := []struct {
+		dotted string
+		expect string
+	}{
+		{"", ""},
+		{"Spec", "Spec"},
+		{"Spec.Name", "Spec.Name"},
+		{"Spec.Rules[0].Port", "Spec.Rules[0].Port"},
+		{"Spec.Rules.[0].Port", "Spec.Rules[0].Port"},
+		{"Spec.Matrix[0][1]", "Spec.Matrix[0][1]"},
+		{"[0].Name", "[0].Name"},
+		{"Spec.Tags[a.b].Value", "Spec.Tags[a.b].Value"},
+		{`Spec.Tags."env"`, "Spec.Tags.env"},
+		{`Spec.Tags."a.b".Value`, `Spec.Tags."a.b".Value`},
+		{`Spec.Rules."a.b"[0]`, `Spec.Rules."a.b"[0]`},
+	}
+	for _, tc := range testCases {
+		require.Equal(tc.expect, compare.NewPath(tc.dotted).String(), tc.dotted)
+	}
+}
+
+func TestPath_QuotedParts(t *testing.T) {
+	require := require.New(t)
+
+	testCases := []struct {
+		parts  []string
+		dotted string
+	}{
+		{
+			[]string{"Spec", "Tags", "kubernetes.io/cluster/x"},
+			`Spec.Tags."kubernetes.io/cluster/x"`,
+		},
+		{[]string{"Spec", "Tags", "a[0]", "Value"}, `Spec.Tags."a[0]".Value`},
+		{[]string{"Spec", "Tags", "a]"}, `Spec.Tags."a]"`},
+		{[]string{"Spec", "Tags", `"a"`}, `Spec.Tags."\"a\""`},
+		{[]string{"Spec", "Tags", `a.\`}, `Spec.Tags."a.\\"`},
+		{[]string{"Spec", "Tags", `a"b`}, `Spec.Tags.a"b`},
+		{[]string{"Spec", "Tags", "a.b", "[0]"}, `Spec.Tags."a.b"[0]`},
+	}
+	for _, tc := range testCases {
+		p := compare.NewPath("")
+		p.Pop()
+		for _, part := range tc.parts {
+			p.Push(part)
+		}
+		require.Equal(tc.dotted, p.String(), tc.dotted)
+		require.Equal(tc.parts, compare.NewPath(p.String()).Parts(), tc.dotted)
+
+		var decoded compare.Path
+		require.NoError(json.Unmarshal([]byte(strconv.Quote(p.String())), &decoded))
+		require.Equal(p, decoded, tc.dotted)
+	}
+
+	// Quoted parts are matched by options and Delta methods
+	a := map[string]string{"kubernetes.io/cluster/x": "owned", "env": "prod"}
+	b := map[string]string{"kubernetes.io/cluster/x": "shared", "env": "prod"}
+	delta, err := compare.Diff(a, b, compare.WithPathPrefix("Spec.Tags"))
+	require.NoError(err)
+	require.Len(delta.Differences, 1)
+	dotted := delta.Differences[0].Path.String()
+	require.Equal(`Spec.Tags."kubernetes.io/cluster/x"`, dotted)
+	require.True(delta.DifferentAt(dotted))
+	require.False(delta.DifferentExcept(dotted))
+
+	delta, err = compare.Diff(
+		a, b,
+		compare.WithPathPrefix("Spec.Tags"),
+		compare.IgnorePaths(dotted),
+	)
+	require.NoError(err)
+	require.Empty(delta.Differences)
+}
+
+func TestPath_Contains(t *testing.T) {
+	require := require.New(t)
+
+	p := compare.NewPath("Spec.Rules[0].Port")
+	require.True(p.Contains("Spec"))
+	require.True(p.Contains("Spec.Rules"))
+	require.True(p.Contains("Spec.Rules[0]"))
+	require.True(p.Contains("Spec.Rules[0].Port"))
+	require.False(p.Contains("Spec.Rules[1]"))
+	require.False(p.Contains("Spec.Rules[0].Port.Number"))
+	require.False(p.Contains("Rules"))
+}
+
+func TestPath_PushPop(t *testing.T) {
+	require := require.New(t)
+
+	p := compare.NewPath("Spec")
+	p.Push("Rules")
+	p.Push("[0]")
+	require.Equal("Spec.Rules[0]", p.String())
+	require.Equal(3, p.Size())
+	require.Equal("Spec", p.Front())
+	require.Equal("[0]", p.Back())
+	require.Equal("Rules", p.At(1))
+	require.Equal("", p.At(3))
+
+	// Pushing onto a copy does not modify the original
+	cp := p
+	cp.Pop()
+	cp.Push("[1]")
+	require.Equal("Spec.Rules[1]", cp.String())
+	require.Equal("Spec.Rules[0]", p.String())
+
+	require.Equal("[0]", p.Pop())
+	require.Equal("Rules", p.Pop())
+	require.Equal("Spec", p.Pop())
+	require.True(p.Empty())
+	require.Equal("", p.Pop())
+	require.Equal("", p.Back())
+
+	// Mutating the returned parts does not modify the Path
+	p = compare.NewPath("Spec.Name")
+	p.Parts()[0] = "Status"
+	require.Equal("Spec.Name", p.String())
+}
+
+func TestPath_FieldPath(t *testing.T) {
+	require := require.New(t)
+
+	testCases := []string{
+		"Spec",
+		"Spec.Name",
+		"Spec.Rules[0].Port",
+		"Spec.Matrix[0][1]",
+		"Spec.Tags[Key=env].Value",
+	}
+	for _, dotted := range testCases {
+		p := compare.NewPath(dotted)
+		fp := p.FieldPath()
+		require.Equal(dotted, fp.String(), dotted)
+		require.Equal(fieldpath.FromString(dotted).Parts(), fp.Parts(), dotted)
+		require.Equal(p, compare.FromFieldPath(fp), dotted)
+	}
+
+	fp := compare.NewPath("Spec.Rules[0].Port").FieldPath()
+	require.True(fp.HasPrefix("Spec.Rules[0]"))
+	require.True(fp.HasPrefixFold("spec.rules[0]"))
+
+	// Map keys containing dots are kept whole
+	p := compare.FromFieldPath(fieldpath.FromParts("Spec", "Labels", "app.kubernetes.io/name"))
+	require.Equal([]string{"Spec", "Labels", "app.kubernetes.io/name"}, p.Parts())
+
+	require.True(compare.FromFieldPath(nil).Empty())
+}
@@ -0,0 +1,115 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare
+
+// pathPattern is a Path in which a "*" part matches any single part, and a
+// "[*]" part matches any list element selector, e.g. "Spec.Rules[*].Port"
+type pathPattern []string
+
+// newPathPattern returns the pathPattern for the supplied dotted-notation
+// string
+func newPathPattern(dotted string) pathPattern {
+	return pathPattern(splitPath(dotted))
+}
+
+// newPathPatterns returns the pathPatterns for the supplied dotted-notation
+// strings
+func newPathPatterns(dotted []string) []pathPattern {
+	patterns := make([]pathPattern, len(dotted))
+	for x, s := range dotted {
+		patterns[x] = newPathPattern(s)
+	}
+	return patterns
+}
+
+// matchPart returns true if the supplied Path part matches the pattern part
+func matchPart(pattern, part string) bool {
+	switch pattern {
+	case "*":
+		return true
+	case "[*]":
+		return isElementPart(part)
+	}
+	return pattern == part
+}
+
+// matches returns true if the pattern matches the supplied Path parts
+// exactly
+func (p pathPattern) matches(parts []string) bool {
+	return len(parts) == len(p) && p.covers(parts)
+}
+
+// covers returns true if the pattern matches the supplied Path parts or a
+// prefix of them, i.e. if the parts are at or beneath the pattern. An empty
+// pattern covers every Path.
+func (p pathPattern) covers(parts []string) bool {
+	if p.isRoot() {
+		return true
+	}
+	if len(parts) < len(p) {
+		return false
+	}
+	for x, part := range p {
+		if !matchPart(part, parts[x]) {
+			return false
+		}
+	}
+	return true
+}
+
+// isRoot returns true if the pattern is the empty pattern
+func (p pathPattern) isRoot() bool {
+	return len(p) == 1 && p[0] == ""
+}
+
+// anyCovers returns true if any of the supplied patterns covers the supplied
+// Path parts
+func anyCovers(patterns []pathPattern, parts []string) bool {
+	for _, p := range patterns {
+		if p.covers(parts) {
+			return true
+		}
+	}
+	return false
+}
+
+// overlaps returns true if the pattern covers the supplied Path parts, or the
+// parts match a prefix of the pattern, i.e. if the parts are at, beneath or
+// above the pattern
+func (p pathPattern) overlaps(parts []string) bool {
+	if len(p) == 1 && p[0] == "" || len(parts) == 1 && parts[0] == "" {
+		return true
+	}
+	n := len(p)
+	if len(parts) < n {
+		n = len(parts)
+	}
+	for x := 0; x < n; x++ {
+		if !matchPart(p[x], parts[x]) {
+			return false
+		}
+	}
+	return true
+}
+
+// anyOverlaps returns true if any of the supplied patterns overlaps the
+// supplied Path parts
+func anyOverlaps(patterns []pathPattern, parts []string) bool {
+	for _, p := range patterns {
+		if p.overlaps(parts) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DefaultPolicyVersion is the policy language version that IAM assumes for
+// policy documents that do not specify a Version
+const DefaultPolicyVersion = "2008-10-17"
+
+// policyStringSetElements are the names of the statement elements whose values
+// are sets of strings, which IAM accepts either as a single string or as an
+// array of strings in any order
+var policyStringSetElements = []string{
+	"Action", "NotAction", "Resource", "NotResource",
+}
+
+// policyPrincipalElements are the names of the statement elements that hold
+// principals, either "*" or an object mapping principal types, e.g. "AWS" or
+// "Service", to sets of strings
+var policyPrincipalElements = []string{"Principal", "NotPrincipal"}
+
+// PolicyDocumentEqual returns true if the supplied strings contain equivalent
+// IAM policy documents, such as identity, bucket, queue or key policies. In
+// addition to the normalization done by JSONStringEqual, the documents are
+// normalized as IAM interprets them:
+//
+//   - A missing Version is DefaultPolicyVersion.
+//   - A single Statement object is a Statement array with one element.
+//   - A Principal or NotPrincipal of "*" is {"AWS": "*"}.
+//   - Action, NotAction, Resource and NotResource values, the values of each
+//     Principal and NotPrincipal type, e.g. "AWS", and Condition values are
+//     sets, so a single string is an array with one element and the order of
+//     array elements does not matter.
+//
+// Statements are compared in order. If the documents are not equivalent, the
+// returned Delta contains the differences between the normalized documents,
+// e.g. at "Statement[0].Action" with both Action arrays sorted. Any supplied
+// Options are passed to Diff, as for JSONStringEqual.
+//
+// An empty or whitespace-only string is treated as a missing document. An
+// error is returned if either string is not a valid JSON object.
+func PolicyDocumentEqual(a, b string, opts ...Option) (bool, *Delta, error) {
+	docA, err := parsePolicyDocument(a)
+	if err != nil {
+		return false, nil, err
+	}
+	docB, err := parsePolicyDocument(b)
+	if err != nil {
+		return false, nil, err
+	}
+	return documentsEqual(docA, docB, opts)
+}
+
+// parsePolicyDocument returns the normalized policy document contained in the
+// supplied string, or nil if the string is empty
+func parsePolicyDocument(s string) (any, error) {
+	doc, err := parseJSONString(s)
+	if err != nil || doc == nil {
+		return doc, err
+	}
+	policy, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf(
+			"invalid policy document: expected a JSON object but got %T", doc,
+		)
+	}
+	if _, ok := policy["Version"]; !ok {
+		policy["Version"] = DefaultPolicyVersion
+	}
+	if statement, ok := policy["Statement"].(map[string]interface{}); ok {
+		policy["Statement"] = []interface{}{statement}
+	}
+	if statements, ok := policy["Statement"].([]interface{}); ok {
+		for _, statement := range statements {
+			if statement, ok := statement.(map[string]interface{}); ok {
+				normalizePolicyStatement(statement)
+			}
+		}
+	}
+	return policy, nil
+}
+
+// normalizePolicyStatement normalizes the set-valued elements of the supplied
+// policy statement in place
+func normalizePolicyStatement(statement map[string]interface{}) {
+	for _, name := range policyStringSetElements {
+		if value, ok := statement[name]; ok {
+			statement[name] = normalizeStringSet(value)
+		}
+	}
+	for _, name := range policyPrincipalElements {
+		if statement[name] == "*" {
+			// IAM treats "*" as {"AWS": "*"}
+			statement[name] = map[string]interface{}{"AWS": "*"}
+		}
+		if principals, ok := statement[name].(map[string]interface{}); ok {
+			for principalType, value := range principals {
+				principals[principalType] = normalizeStringSet(value)
+			}
+		}
+	}
+	if condition, ok := statement["Condition"].(map[string]interface{}); ok {
+		for _, operands := range condition {
+			if operands, ok := operands.(map[string]interface{}); ok {
+				for key, value := range operands {
+					operands[key] = normalizeStringSet(value)
+				}
+			}
+		}
+	}
+}
+
+// normalizeStringSet returns the supplied policy element value as a sorted
+// array if it is a string or an array of strings, and unchanged otherwise
+func normalizeStringSet(value any) any {
+	switch v := value.(type) {
+	case string:
+		return []interface{}{v}
+	case []interface{}:
+		strs := make([]string, len(v))
+		for x, elem := range v {
+			s, ok := elem.(string)
+			if !ok {
+				return value
+			}
+			strs[x] = s
+		}
+		sort.Strings(strs)
+		for x, s := range strs {
+			v[x] = s
+		}
+		return v
+	}
+	return value
+}
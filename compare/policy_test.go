@@ -0,0 +1,110 @@
+package compare_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/pkg/compare"
+)
+
+func TestPolicyDocumentEqual(t *testing.T) {
+	require := require.New(t)
+
+	desired := `{
+		"Version": "2012-10-17",
+		"Statement": [{
+			"Effect": "Allow",
+			"Principal": {"Service": ["lambda.amazonaws.com"]},
+			"Action": ["s3:PutObject", "s3:GetObject"],
+			"Resource": "arn:aws:s3:::bucket/*",
+			"Condition": {"StringEquals": {"aws:SourceAccount": ["111122223333"]}}
+		}]
+	}`
+	// The same policy as returned by IAM: URL-encoded, with keys reordered
+	// and single-element arrays collapsed to strings
+	latest := "%7B%22Statement%22%3A%7B%22Resource%22%3A%5B%22arn%3Aaws%3As3%3A%3A%3Abucket%2F%2A%22%5D%2C" +
+		"%22Action%22%3A%5B%22s3%3AGetObject%22%2C%22s3%3APutObject%22%5D%2C%22Effect%22%3A%22Allow%22%2C" +
+		"%22Condition%22%3A%7B%22StringEquals%22%3A%7B%22aws%3ASourceAccount%22%3A%22111122223333%22%7D%7D%2C" +
+		"%22Principal%22%3A%7B%22Service%22%3A%22lambda.amazonaws.com%22%7D%7D%2C%22Version%22%3A%222012-10-17%22%7D"
+
+	testCases := []struct {
+		a, b   string
+		expect bool
+		paths  []string
+	}{
+		{desired, latest, true, nil},
+		{
+			`{"Statement":[{"Effect":"Allow","Action":"*","Resource":"*"}]}`,
+			`{"Version":"2008-10-17","Statement":{"Effect":"Allow","Action":["*"],"Resource":["*"]}}`,
+			true, nil,
+		},
+		{
+			`{"Statement":{"Effect":"Allow","Action":"*","Resource":"*"}}`,
+			`{"Version":"2012-10-17","Statement":{"Effect":"Allow","Action":"*","Resource":"*"}}`,
+			false, []string{"Version"},
+		},
+		{
+			`{"Version":"2012-10-17","Statement":{"Effect":"Allow","Action":["s3:GetObject","s3:PutObject"],"Resource":"*"}}`,
+			`{"Version":"2012-10-17","Statement":{"Effect":"Allow","Action":["s3:PutObject","s3:DeleteObject"],"Resource":"*"}}`,
+			false, []string{"Statement[0].Action[0]"},
+		},
+		{
+			`{"Version":"2012-10-17","Statement":[{"Sid":"a","Effect":"Allow","Action":"*","Resource":"*"},{"Sid":"b","Effect":"Deny","Action":"*","Resource":"*"}]}`,
+			`{"Version":"2012-10-17","Statement":[{"Sid":"b","Effect":"Deny","Action":"*","Resource":"*"},{"Sid":"a","Effect":"Allow","Action":"*","Resource":"*"}]}`,
+			false, []string{"Statement[0].Effect", "Statement[0].Sid", "Statement[1].Effect", "Statement[1].Sid"},
+		},
+		{
+			`{"Version":"2012-10-17","Statement":{"Effect":"Allow","Principal":"*","Action":"*","Resource":"*"}}`,
+			`{"Version":"2012-10-17","Statement":{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":"*","Resource":"*"}}`,
+			true, nil,
+		},
+		{
+			`{"Version":"2012-10-17","Statement":{"Effect":"Deny","NotPrincipal":"*","Action":"*","Resource":"*"}}`,
+			`{"Version":"2012-10-17","Statement":{"Effect":"Deny","NotPrincipal":{"AWS":"arn:aws:iam::123456789012:root"},"Action":"*","Resource":"*"}}`,
+			false, []string{"Statement[0].NotPrincipal.AWS[0]"},
+		},
+		{"", "", true, nil},
+	}
+	for _, tc := range testCases {
+		msg := fmt.Sprintf("for %s and %s", tc.a, tc.b)
+		equal, delta, err := compare.PolicyDocumentEqual(tc.a, tc.b)
+		require.NoError(err, msg)
+		require.Equal(tc.expect, equal, msg)
+		paths := []string{}
+		for _, p := range delta.Paths() {
+			paths = append(paths, p.String())
+		}
+		if tc.paths == nil {
+			tc.paths = []string{}
+		}
+		require.Equal(tc.paths, paths, msg)
+	}
+}
+
+func TestPolicyDocumentEqual_Delta(t *testing.T) {
+	require := require.New(t)
+
+	equal, delta, err := compare.PolicyDocumentEqual(
+		`{"Version":"2012-10-17","Statement":{"Effect":"Allow","Action":["sqs:SendMessage","sqs:ReceiveMessage"],"Resource":"*"}}`,
+		`{"Version":"2012-10-17","Statement":{"Effect":"Allow","Action":"sqs:SendMessage","Resource":"*"}}`,
+		compare.WithPathPrefix("Spec.Policy"),
+	)
+	require.NoError(err)
+	require.False(equal)
+	require.Len(delta.Differences, 1)
+	diff := delta.Differences[0]
+	require.Equal("Spec.Policy.Statement[0].Action", diff.Path.String())
+	require.Equal([]interface{}{"sqs:ReceiveMessage", "sqs:SendMessage"}, diff.A)
+	require.Equal([]interface{}{"sqs:SendMessage"}, diff.B)
+}
+
+func TestPolicyDocumentEqual_Invalid(t *testing.T) {
+	require := require.New(t)
+
+	_, _, err := compare.PolicyDocumentEqual(`["Statement"]`, `{}`)
+	require.Error(err)
+	_, _, err = compare.PolicyDocumentEqual(`{}`, `{"Statement":`)
+	require.Error(err)
+}
@@ -0,0 +1,181 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+const (
+	// RedactedValue replaces the values of sensitive Differences when a Delta
+	// is rendered or serialized
+	RedactedValue = "<redacted>"
+	// redactedHashPrefix prefixes the keyed hash of a redacted value
+	redactedHashPrefix = "hmac-sha256:"
+)
+
+// SensitivePaths marks the Differences at, beneath or above the supplied
+// dotted-notation path patterns as sensitive, using the same syntax as
+// IgnorePaths. For example:
+//
+//	compare.SensitivePaths("Spec.MasterUserPassword", "Spec.AuthToken")
+//
+// A Difference above a sensitive path, e.g. one recorded for the whole of
+// "Spec" when it is nil in one of the compared values, is sensitive because
+// its values contain the sensitive field.
+//
+// The A and B values of a sensitive Difference are redacted whenever the
+// Delta is rendered, e.g. by Delta.String, or serialized, e.g. by
+// json.Marshal. Comparisons, and Delta.ApplyTo, use the real values, and a
+// redacted value decoded from JSON cannot be applied.
+func SensitivePaths(patterns ...string) Option {
+	return func(o *options) {
+		o.sensitive = append(o.sensitive, newPathPatterns(patterns)...)
+	}
+}
+
+// SensitiveTypes marks the Differences whose values are, point to or contain
+// values of the supplied types as sensitive. See SensitivePaths.
+func SensitiveTypes(types ...reflect.Type) Option {
+	return func(o *options) {
+		if o.sensitiveTypes == nil {
+			o.sensitiveTypes = map[reflect.Type]bool{}
+		}
+		for _, t := range types {
+			o.sensitiveTypes[t] = true
+		}
+	}
+}
+
+// MarkSensitive marks the differences at, beneath or above the supplied
+// dotted-notation path patterns as sensitive. It is useful for Deltas built
+// using Add. See SensitivePaths.
+func (d *Delta) MarkSensitive(patterns ...string) {
+	sensitive := newPathPatterns(patterns)
+	for _, diff := range d.Differences {
+		if anyOverlaps(sensitive, diff.Path.parts) {
+			diff.Sensitive = true
+		}
+	}
+}
+
+// hasSensitive returns the first sensitive difference in the delta, or nil
+func (d *Delta) hasSensitive() *Difference {
+	for _, diff := range d.Differences {
+		if diff.Sensitive {
+			return diff
+		}
+	}
+	return nil
+}
+
+// hasRedacted returns the first difference in the delta whose value for the
+// supplied side was redacted when the difference was serialized, or nil
+func (d *Delta) hasRedacted(side Side) *Difference {
+	for _, diff := range d.Differences {
+		value := diff.A
+		if side == SideB {
+			value = diff.B
+		}
+		if diff.redactedValues && !isNilValue(value) {
+			return diff
+		}
+	}
+	return nil
+}
+
+// redacted returns the values of the difference to render or serialize. If
+// the difference is sensitive, non-nil values are replaced by RedactedValue
+// or, if a hash key is supplied, by a keyed hash of the value.
+func (d *Difference) redacted(hashKey []byte) (any, any) {
+	if !d.Sensitive {
+		return d.A, d.B
+	}
+	return redactValue(d.A, hashKey), redactValue(d.B, hashKey)
+}
+
+// redactValue returns the replacement for a sensitive value. Nil values are
+// not replaced, so that whether a sensitive field is set is not hidden. The
+// hash is an HMAC rather than a plain digest, so that low-entropy values such
+// as passwords cannot be recovered from it by guessing without the key.
+func redactValue(v any, hashKey []byte) any {
+	if isNilValue(v) {
+		return nil
+	}
+	if len(hashKey) == 0 {
+		return RedactedValue
+	}
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		encoded = []byte(fmt.Sprintf("%#v", v))
+	}
+	mac := hmac.New(sha256.New, hashKey)
+	mac.Write(encoded)
+	return redactedHashPrefix + hex.EncodeToString(mac.Sum(nil)[:8])
+}
+
+// isSensitive returns true if a difference with the supplied values at the
+// supplied path is sensitive
+func (o *options) isSensitive(path []pathPart, a, b any) bool {
+	if len(o.sensitive) > 0 && anyOverlaps(o.sensitive, partNames(path)) {
+		return true
+	}
+	if len(o.sensitiveTypes) == 0 {
+		return false
+	}
+	for _, v := range []any{a, b} {
+		if v != nil && containsType(reflect.TypeOf(v), o.sensitiveTypes, nil) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsType returns true if the supplied type is, or its values point to
+// or contain values of, any of the supplied types
+func containsType(
+	t reflect.Type,
+	types map[reflect.Type]bool,
+	visited map[reflect.Type]bool,
+) bool {
+	if types[t] {
+		return true
+	}
+	if visited[t] {
+		return false
+	}
+	if visited == nil {
+		visited = map[reflect.Type]bool{}
+	}
+	visited[t] = true
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return containsType(t.Elem(), types, visited)
+	case reflect.Map:
+		return containsType(t.Key(), types, visited) ||
+			containsType(t.Elem(), types, visited)
+	case reflect.Struct:
+		for x := 0; x < t.NumField(); x++ {
+			if containsType(t.Field(x).Type, types, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,200 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/pkg/compare"
+)
+
+type testSecret string
+
+type testCredentials struct {
+	Username *string
+	Password *testSecret
+}
+
+type testDatabase struct {
+	Name                  *string
+	MasterUserPassword    *string
+	Credentials           *testCredentials
+	AdditionalCredentials []*testCredentials
+}
+
+func secretP(s string) *testSecret {
+	v := testSecret(s)
+	return &v
+}
+
+func TestDiff_SensitivePaths(t *testing.T) {
+	require := require.New(t)
+
+	a := testDatabase{
+		Name:               strP("db"),
+		MasterUserPassword: strP("hunter2"),
+	}
+	b := testDatabase{
+		Name:               strP("db-renamed"),
+		MasterUserPassword: strP("correct-horse"),
+	}
+
+	delta, err := compare.Diff(a, b, compare.SensitivePaths("MasterUserPassword"))
+	require.NoError(err)
+	require.Len(delta.Differences, 2)
+	require.False(delta.Differences[0].Sensitive)
+	require.True(delta.Differences[1].Sensitive)
+	// The real values are kept for comparisons
+	require.Equal(a.MasterUserPassword, delta.Differences[1].A)
+
+	rendered := delta.String()
+	require.Equal(
+		`MasterUserPassword: <redacted> -> <redacted>; Name: "db" -> "db-renamed"`,
+		rendered,
+	)
+	rendered = delta.Format(compare.FormatOptions{MultiLine: true})
+	require.NotContains(rendered, "hunter2")
+	require.NotContains(rendered, "correct-horse")
+
+	// Hashes are stable and differ between values
+	key := compare.FormatOptions{SensitiveValueHashKey: []byte("key")}
+	hashed := delta.Format(key)
+	require.NotContains(hashed, "hunter2")
+	require.Equal(hashed, delta.Format(key))
+	parts := strings.Split(strings.Split(hashed, ";")[0], " -> ")
+	require.True(strings.HasPrefix(parts[1], "hmac-sha256:"), hashed)
+	require.NotEqual(strings.TrimPrefix(parts[0], "MasterUserPassword: "), parts[1])
+	// Hashes depend on the key
+	otherKey := compare.FormatOptions{SensitiveValueHashKey: []byte("other")}
+	require.NotEqual(hashed, delta.Format(otherKey))
+	require.Contains(hashed, "db-renamed")
+
+	// Difference values, not only pointers, are redacted
+	diff := *delta.Differences[1]
+	require.NotContains(fmt.Sprintf("%v", diff), "hunter2")
+	require.NotContains(fmt.Sprint(diff), "hunter2")
+	encodedDiff, err := json.Marshal(diff)
+	require.NoError(err)
+	require.NotContains(string(encodedDiff), "hunter2")
+	encodedDiff, err = json.Marshal([]compare.Difference{diff})
+	require.NoError(err)
+	require.NotContains(string(encodedDiff), "hunter2")
+	encodedDelta, err := json.Marshal(*delta)
+	require.NoError(err)
+	require.NotContains(string(encodedDelta), "hunter2")
+	require.Contains(string(encodedDelta), `"Version":1`)
+
+	encoded, err := json.Marshal(delta)
+	require.NoError(err)
+	require.NotContains(string(encoded), "hunter2")
+	require.Contains(string(encoded), "db-renamed")
+	var decoded struct {
+		Differences []struct {
+			A, B      interface{}
+			Sensitive bool
+		}
+	}
+	require.NoError(json.Unmarshal(encoded, &decoded))
+	require.Equal(compare.RedactedValue, decoded.Differences[1].A)
+	require.Equal(compare.RedactedValue, decoded.Differences[1].B)
+	require.True(decoded.Differences[1].Sensitive)
+
+	for _, op := range delta.JSONPatchOperations() {
+		if op.Path == "/MasterUserPassword" {
+			require.Equal(compare.RedactedValue, op.Value)
+		}
+	}
+	_, err = delta.JSONPatch()
+	require.Error(err)
+	_, err = delta.MergePatch()
+	require.Error(err)
+}
+
+func TestDiff_SensitivePaths_Containing(t *testing.T) {
+	require := require.New(t)
+
+	a := testResource{}
+	b := testResource{Spec: testSpec{Config: &testConfig{Name: strP("secret-name")}}}
+
+	// A difference above a sensitive path contains the sensitive value
+	delta, err := compare.Diff(a, b, compare.SensitivePaths("Spec.Config.Name"))
+	require.NoError(err)
+	require.Len(delta.Differences, 1)
+	require.True(delta.Differences[0].Sensitive)
+	require.Equal("Spec.Config: <nil> -> <redacted>", delta.String())
+
+	delta, err = compare.Diff(a, b, compare.SensitivePaths("Spec.Config.Enabled"))
+	require.NoError(err)
+	require.True(delta.Differences[0].Sensitive)
+
+	delta, err = compare.Diff(a, b, compare.SensitivePaths("Spec.Engine"))
+	require.NoError(err)
+	require.False(delta.Differences[0].Sensitive)
+}
+
+func TestDiff_SensitiveTypes(t *testing.T) {
+	require := require.New(t)
+
+	a := testDatabase{
+		Name:        strP("db"),
+		Credentials: &testCredentials{Username: strP("admin"), Password: secretP("a")},
+	}
+	b := testDatabase{
+		Name:        strP("db"),
+		Credentials: &testCredentials{Username: strP("root"), Password: secretP("b")},
+		AdditionalCredentials: []*testCredentials{
+			{Username: strP("reader"), Password: secretP("c")},
+		},
+	}
+
+	delta, err := compare.Diff(
+		a, b, compare.SensitiveTypes(reflect.TypeOf(testSecret(""))),
+	)
+	require.NoError(err)
+	sensitive := map[string]bool{}
+	for _, diff := range delta.Differences {
+		sensitive[diff.Path.String()] = diff.Sensitive
+	}
+	require.Equal(map[string]bool{
+		"Credentials.Username":  false,
+		"Credentials.Password":  true,
+		"AdditionalCredentials": true,
+	}, sensitive)
+	require.NotContains(delta.String(), `"c"`)
+}
+
+func TestDelta_MarkSensitive(t *testing.T) {
+	require := require.New(t)
+
+	delta := compare.NewDelta()
+	delta.Add("Spec.AuthToken", strP("old-token"), strP("new-token"))
+	delta.Add("Spec.Users[0].Password", nil, strP("pw"))
+	delta.Add("Spec.Users[0].Name", nil, strP("alice"))
+	delta.Add("Spec.Port", int64P(6379), int64P(6380))
+	delta.MarkSensitive("Spec.AuthToken", "Spec.Users[*].Password")
+
+	require.Equal(
+		`Spec.AuthToken: <redacted> -> <redacted>; `+
+			`Spec.Port: 6379 -> 6380; `+
+			`Spec.Users[0].Name: <nil> -> "alice"; `+
+			`Spec.Users[0].Password: <nil> -> <redacted>`,
+		delta.String(),
+	)
+}
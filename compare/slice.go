@@ -13,51 +13,220 @@
 
 package compare
 
-import "sort"
-
 // SliceStringPEqual returns true if the supplied slices of string pointers
 // have equal values regardless of order.
 func SliceStringPEqual(a, b []*string) bool {
-	if len(a) != len(b) {
-		return false
-	}
-	sa := make([]string, len(a))
-	sb := make([]string, len(a))
-	for x, aPtr := range a {
-		sa[x] = *aPtr
-		sb[x] = *b[x]
+	return SlicePEqualUnordered(a, b)
+}
+
+// SliceStringPEqualWithOptions returns true if the supplied slices of string
+// pointers have equal values regardless of order. The supplied Options are
+// applied to the comparison, e.g. IgnoreCase().
+func SliceStringPEqualWithOptions(a, b []*string, opts ...Option) bool {
+	if len(opts) > 0 {
+		return equalWithOptions(a, b, opts)
 	}
-	sort.Strings(sa)
-	sort.Strings(sb)
-	return sortedStringSliceEqual(sa, sb)
+	return SliceStringPEqual(a, b)
 }
 
 // SliceStringEqual returns true if the supplied slices of string
 // have equal values regardless of order.
 func SliceStringEqual(a, b []string) bool {
+	return SliceEqualUnordered(a, b)
+}
+
+// SliceStringEqualWithOptions returns true if the supplied slices of string
+// have equal values regardless of order. The supplied Options are applied to
+// the comparison, e.g. IgnoreCase().
+func SliceStringEqualWithOptions(a, b []string, opts ...Option) bool {
+	if len(opts) > 0 {
+		return equalWithOptions(a, b, opts)
+	}
+	return SliceStringEqual(a, b)
+}
+
+// SliceEqualOrdered returns true if the supplied slices have equal elements
+// in the same order. A nil slice is equal to an empty one.
+func SliceEqualOrdered[T comparable](a, b []T) bool {
+	return SliceEqualOrderedFunc(a, b, func(x, y T) bool { return x == y })
+}
+
+// SliceEqualOrderedFunc returns true if the supplied slices have elements in
+// the same order for which the supplied function returns true. A nil slice is
+// equal to an empty one.
+func SliceEqualOrderedFunc[T any](a, b []T, eq func(T, T) bool) bool {
 	if len(a) != len(b) {
 		return false
 	}
-	aCopy := make([]string, len(a))
-	bCopy := make([]string, len(a))
-	for x, aVal := range a {
-		aCopy[x] = aVal
-		bCopy[x] = b[x]
+	for x := range a {
+		if !eq(a[x], b[x]) {
+			return false
+		}
 	}
-	sort.Strings(aCopy)
-	sort.Strings(bCopy)
-	return sortedStringSliceEqual(aCopy, bCopy)
+	return true
+}
+
+// SliceEqualUnordered returns true if the supplied slices have equal elements
+// regardless of order. Each element must appear the same number of times in
+// both slices. A nil slice is equal to an empty one.
+func SliceEqualUnordered[T comparable](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[T]int, len(a))
+	for _, aVal := range a {
+		counts[aVal]++
+	}
+	for _, bVal := range b {
+		if counts[bVal] == 0 {
+			return false
+		}
+		counts[bVal]--
+	}
+	return true
 }
 
-// sortedStringSliceEqual returns true if the supplied sorted slices of string
-// have equal values considering the order. It is assumed the size is same for
-// both slices.
-func sortedStringSliceEqual(a, b []string) bool {
-	for x, aVal := range a {
-		bVal := b[x]
-		if aVal != bVal {
+// SliceEqualUnorderedFunc returns true if each element of the first slice can
+// be paired with a distinct element of the second slice for which the
+// supplied function returns true, regardless of order. The function must be
+// an equivalence relation. A nil slice is equal to an empty one.
+//
+// SliceEqualUnorderedFunc compares every pair of elements, so it is best
+// suited to short slices of values that are not comparable, e.g.:
+//
+//	compare.SliceEqualUnorderedFunc(a.Rules, b.Rules, func(x, y *svcapitypes.Rule) bool {
+//	    return reflect.DeepEqual(x, y)
+//	})
+func SliceEqualUnorderedFunc[T any](a, b []T, eq func(T, T) bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	matched := make([]bool, len(b))
+	for _, aVal := range a {
+		found := false
+		for y, bVal := range b {
+			if !matched[y] && eq(aVal, bVal) {
+				matched[y] = true
+				found = true
+				break
+			}
+		}
+		if !found {
 			return false
 		}
 	}
 	return true
 }
+
+// SlicePEqualOrdered returns true if the supplied slices of pointers point to
+// equal values in the same order. See PEqual for the treatment of nil
+// pointers and the Options that apply.
+func SlicePEqualOrdered[T comparable](a, b []*T, opts ...Option) bool {
+	return SliceEqualOrderedFunc(a, b, func(x, y *T) bool {
+		return PEqual(x, y, opts...)
+	})
+}
+
+// SlicePEqualUnordered returns true if the supplied slices of pointers point
+// to equal values regardless of order. Each value must appear the same number
+// of times in both slices. See PEqual for the treatment of nil pointers and
+// the Options that apply.
+func SlicePEqualUnordered[T comparable](a, b []*T, opts ...Option) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	nilEqualsZero := newOptions(opts).nilEqualsZero
+	counts := make(map[T]int, len(a))
+	nils := 0
+	for _, aPtr := range a {
+		if aPtr == nil && !nilEqualsZero {
+			nils++
+			continue
+		}
+		counts[derefOrZero(aPtr)]++
+	}
+	for _, bPtr := range b {
+		if bPtr == nil && !nilEqualsZero {
+			nils--
+			continue
+		}
+		bVal := derefOrZero(bPtr)
+		if counts[bVal] == 0 {
+			return false
+		}
+		counts[bVal]--
+	}
+	return nils == 0
+}
+
+// SliceDelta contains the result of comparing two slices regardless of order
+// using UnorderedDiff. Duplicate elements are significant: an element whose
+// key appears n more times in the second slice than in the first appears n
+// times in Added, and vice versa for Removed.
+type SliceDelta[T any] struct {
+	// Added contains the elements of the second slice whose keys are not
+	// matched in the first, in the order they appear in the second slice
+	Added []T
+	// Removed contains the elements of the first slice whose keys are not
+	// matched in the second, in the order they appear in the first slice
+	Removed []T
+	// Common contains the elements of the first slice whose keys are matched
+	// in the second, in the order they appear in the first slice
+	Common []T
+}
+
+// Equal returns true if no elements were added or removed
+func (d SliceDelta[T]) Equal() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+// UnorderedDiff compares the supplied slices as multisets of elements
+// identified by the supplied key function, and returns the elements added to
+// and removed from the first slice to produce the second, as well as those
+// common to both. Elements with equal keys are considered equal. For example,
+// to drive the AuthorizeSecurityGroupIngress and RevokeSecurityGroupIngress
+// EC2 API calls:
+//
+//	delta := compare.UnorderedDiff(
+//	    latest.ko.Spec.IngressRules, desired.ko.Spec.IngressRules,
+//	    func(r *svcapitypes.IPPermission) string { return ruleKey(r) },
+//	)
+//	if len(delta.Removed) > 0 {
+//	    err = rm.revokeIngress(ctx, latest, delta.Removed)
+//	    ...
+//	}
+//	if len(delta.Added) > 0 {
+//	    err = rm.authorizeIngress(ctx, latest, delta.Added)
+//	    ...
+//	}
+func UnorderedDiff[T any, K comparable](
+	from, to []T,
+	key func(T) K,
+) SliceDelta[T] {
+	delta := SliceDelta[T]{}
+	counts := make(map[K]int, len(to))
+	for _, toVal := range to {
+		counts[key(toVal)]++
+	}
+	for _, fromVal := range from {
+		k := key(fromVal)
+		if counts[k] > 0 {
+			counts[k]--
+			delta.Common = append(delta.Common, fromVal)
+			continue
+		}
+		delta.Removed = append(delta.Removed, fromVal)
+	}
+	for x := len(to) - 1; x >= 0; x-- {
+		k := key(to[x])
+		if counts[k] > 0 {
+			counts[k]--
+			delta.Added = append(delta.Added, to[x])
+		}
+	}
+	// Added was filled from the end of the second slice
+	for x, y := 0, len(delta.Added)-1; x < y; x, y = x+1, y-1 {
+		delta.Added[x], delta.Added[y] = delta.Added[y], delta.Added[x]
+	}
+	return delta
+}
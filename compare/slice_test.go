@@ -14,6 +14,7 @@
 package compare_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -112,3 +113,124 @@ func TestSliceStringEqual(t *testing.T) {
 	require.True(compare.SliceStringEqual(aab, aba))
 	require.False(compare.SliceStringEqual(aab, bba))
 }
+
+func TestSliceEqualOrdered(t *testing.T) {
+	require := require.New(t)
+
+	require.True(compare.SliceEqualOrdered([]int64{}, nil))
+	require.True(compare.SliceEqualOrdered([]int64{1, 2, 3}, []int64{1, 2, 3}))
+	require.False(compare.SliceEqualOrdered([]int64{1, 2, 3}, []int64{3, 2, 1}))
+	require.False(compare.SliceEqualOrdered([]int64{1, 2}, []int64{1, 2, 3}))
+
+	sameLength := func(x, y string) bool { return len(x) == len(y) }
+	require.True(compare.SliceEqualOrderedFunc(
+		[]string{"a", "bb"}, []string{"c", "dd"}, sameLength,
+	))
+	require.False(compare.SliceEqualOrderedFunc(
+		[]string{"a", "bb"}, []string{"dd", "c"}, sameLength,
+	))
+}
+
+func TestSliceEqualUnordered(t *testing.T) {
+	require := require.New(t)
+
+	require.True(compare.SliceEqualUnordered([]bool{}, nil))
+	require.True(compare.SliceEqualUnordered([]int64{1, 2, 3}, []int64{3, 1, 2}))
+	require.True(compare.SliceEqualUnordered([]int64{1, 1, 2}, []int64{1, 2, 1}))
+	require.False(compare.SliceEqualUnordered([]int64{1, 1, 2}, []int64{1, 2, 2}))
+	require.False(compare.SliceEqualUnordered([]int64{1, 2}, []int64{1, 2, 3}))
+
+	type rule struct {
+		Port  *int64
+		CIDRs []string
+	}
+	ruleEqual := func(x, y *rule) bool {
+		return compare.PEqual(x.Port, y.Port) &&
+			compare.SliceEqualUnordered(x.CIDRs, y.CIDRs)
+	}
+	a := []*rule{
+		{Port: int64P(80), CIDRs: []string{"10.0.0.0/8", "192.168.0.0/16"}},
+		{Port: int64P(443), CIDRs: []string{"10.0.0.0/8"}},
+	}
+	b := []*rule{
+		{Port: int64P(443), CIDRs: []string{"10.0.0.0/8"}},
+		{Port: int64P(80), CIDRs: []string{"192.168.0.0/16", "10.0.0.0/8"}},
+	}
+	require.True(compare.SliceEqualUnorderedFunc(a, b, ruleEqual))
+	b[0].Port = int64P(8443)
+	require.False(compare.SliceEqualUnorderedFunc(a, b, ruleEqual))
+}
+
+func TestSlicePEqual(t *testing.T) {
+	require := require.New(t)
+
+	a := []*int64{int64P(1), int64P(2), int64P(2)}
+	require.True(compare.SlicePEqualOrdered(a, []*int64{int64P(1), int64P(2), int64P(2)}))
+	require.False(compare.SlicePEqualOrdered(a, []*int64{int64P(2), int64P(1), int64P(2)}))
+	require.True(compare.SlicePEqualUnordered(a, []*int64{int64P(2), int64P(1), int64P(2)}))
+	require.False(compare.SlicePEqualUnordered(a, []*int64{int64P(2), int64P(1), int64P(1)}))
+
+	bools := []*bool{boolP(true), boolP(false)}
+	require.True(compare.SlicePEqualUnordered(bools, []*bool{boolP(false), boolP(true)}))
+	require.False(compare.SlicePEqualUnordered(bools, []*bool{boolP(true), boolP(true)}))
+}
+
+func TestUnorderedDiff(t *testing.T) {
+	require := require.New(t)
+
+	identity := func(s string) string { return s }
+
+	testCases := []struct {
+		from    []string
+		to      []string
+		added   []string
+		removed []string
+		common  []string
+	}{
+		{nil, nil, nil, nil, nil},
+		{[]string{"a"}, nil, nil, []string{"a"}, nil},
+		{nil, []string{"a"}, []string{"a"}, nil, nil},
+		{[]string{"a", "b"}, []string{"b", "a"}, nil, nil, []string{"a", "b"}},
+		{[]string{"a", "b"}, []string{"b", "c"}, []string{"c"}, []string{"a"}, []string{"b"}},
+		// Duplicates are counted
+		{[]string{"a", "a", "b"}, []string{"a", "b", "b"}, []string{"b"}, []string{"a"}, []string{"a", "b"}},
+		{[]string{"a"}, []string{"a", "a", "a"}, []string{"a", "a"}, nil, []string{"a"}},
+		{[]string{"a", "a", "a"}, []string{"a"}, nil, []string{"a", "a"}, []string{"a"}},
+		// Added elements keep the order of the second slice
+		{[]string{"b"}, []string{"d", "b", "c", "a"}, []string{"d", "c", "a"}, nil, []string{"b"}},
+	}
+	for _, tc := range testCases {
+		delta := compare.UnorderedDiff(tc.from, tc.to, identity)
+		msg := fmt.Sprintf("for from %v to %v", tc.from, tc.to)
+		require.Equal(tc.added, delta.Added, msg)
+		require.Equal(tc.removed, delta.Removed, msg)
+		require.Equal(tc.common, delta.Common, msg)
+		require.Equal(len(tc.added) == 0 && len(tc.removed) == 0, delta.Equal(), msg)
+	}
+}
+
+func TestUnorderedDiff_Structs(t *testing.T) {
+	require := require.New(t)
+
+	type ipRange struct {
+		CIDR        *string
+		Description *string
+	}
+	key := func(r *ipRange) string { return *r.CIDR }
+
+	from := []*ipRange{
+		{CIDR: strP("10.0.0.0/8")},
+		{CIDR: strP("192.168.0.0/16")},
+		{CIDR: strP("192.168.0.0/16")},
+	}
+	to := []*ipRange{
+		{CIDR: strP("172.16.0.0/12")},
+		{CIDR: strP("10.0.0.0/8"), Description: strP("office")},
+		{CIDR: strP("192.168.0.0/16")},
+	}
+	delta := compare.UnorderedDiff(from, to, key)
+	require.Equal([]*ipRange{to[0]}, delta.Added)
+	require.Equal([]*ipRange{from[2]}, delta.Removed)
+	require.Equal([]*ipRange{from[0], from[1]}, delta.Common)
+	require.False(delta.Equal())
+}
@@ -0,0 +1,81 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare
+
+// ThreeWayDelta separates the differences between the desired and latest
+// states of a resource according to which side changed relative to a common
+// base state, e.g. the state last observed or applied by a controller.
+type ThreeWayDelta struct {
+	// UserChanges contains the differences between the base and desired
+	// states, i.e. changes made by the user to the resource's spec
+	UserChanges *Delta
+	// Drift contains the differences between the base and latest states,
+	// i.e. changes made to the resource outside of the controller
+	Drift *Delta
+	// Conflicts contains the differences between the desired (A) and latest
+	// (B) states at paths that were changed on both sides
+	Conflicts *Delta
+}
+
+// HasConflicts returns true if any path was changed differently by the user
+// and outside of the controller
+func (t *ThreeWayDelta) HasConflicts() bool {
+	return len(t.Conflicts.Differences) > 0
+}
+
+// ThreeWay compares the desired and latest states of a resource against the
+// base state they both derive from. The supplied values must all be of the
+// same type and the supplied Options are applied to every comparison.
+//
+// A difference between desired and latest is a conflict if both the desired
+// and latest states differ from the base state at the same path, or at a
+// path beneath or above it, e.g. the user changed "Spec.Config" while
+// "Spec.Config.Name" was changed out-of-band. Paths changed on both sides to
+// the same value are not conflicts.
+func ThreeWay(base, desired, latest any, opts ...Option) (*ThreeWayDelta, error) {
+	userChanges, err := Diff(base, desired, opts...)
+	if err != nil {
+		return nil, err
+	}
+	drift, err := Diff(base, latest, opts...)
+	if err != nil {
+		return nil, err
+	}
+	diverged, err := Diff(desired, latest, opts...)
+	if err != nil {
+		return nil, err
+	}
+	conflicts := NewDelta()
+	for _, diff := range diverged.Differences {
+		if userChanges.overlaps(diff.Path) && drift.overlaps(diff.Path) {
+			conflicts.Differences = append(conflicts.Differences, diff)
+		}
+	}
+	return &ThreeWayDelta{
+		UserChanges: userChanges,
+		Drift:       drift,
+		Conflicts:   conflicts,
+	}, nil
+}
+
+// overlaps returns true if the delta contains a difference at, beneath or
+// above the supplied path
+func (d *Delta) overlaps(path Path) bool {
+	for _, diff := range d.Differences {
+		if diff.Path.overlaps(path) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/pkg/compare"
+)
+
+func TestThreeWay(t *testing.T) {
+	require := require.New(t)
+
+	newResource := func() testResource {
+		return testResource{
+			Spec: testSpec{
+				AllocatedStorage: int64P(20),
+				Engine:           strP("postgres"),
+				Config:           &testConfig{Name: strP("cfg")},
+				Labels:           map[string]*string{"env": strP("dev")},
+			},
+		}
+	}
+
+	base := newResource()
+	desired := newResource()
+	latest := newResource()
+
+	// Changed by the user only
+	desired.Spec.AllocatedStorage = int64P(40)
+	// Changed out-of-band only
+	latest.Spec.Labels["owner"] = strP("console")
+	// Changed on both sides to the same value
+	desired.Spec.Engine = strP("mysql")
+	latest.Spec.Engine = strP("mysql")
+	// Changed on both sides to different values
+	desired.Spec.Labels["env"] = strP("prod")
+	latest.Spec.Labels["env"] = strP("test")
+	// Changed on both sides at overlapping paths
+	desired.Spec.Config = nil
+	latest.Spec.Config.Enabled = boolP(true)
+
+	delta, err := compare.ThreeWay(base, desired, latest)
+	require.NoError(err)
+
+	require.True(delta.UserChanges.DifferentAt("Spec.AllocatedStorage"))
+	require.True(delta.UserChanges.DifferentAt("Spec.Engine"))
+	require.True(delta.UserChanges.DifferentAt("Spec.Labels.env"))
+	require.True(delta.UserChanges.DifferentAt("Spec.Config"))
+	require.False(delta.UserChanges.DifferentAt("Spec.Labels.owner"))
+	require.Len(delta.UserChanges.Differences, 4)
+
+	require.True(delta.Drift.DifferentAt("Spec.Labels.owner"))
+	require.True(delta.Drift.DifferentAt("Spec.Engine"))
+	require.True(delta.Drift.DifferentAt("Spec.Labels.env"))
+	require.True(delta.Drift.DifferentAt("Spec.Config.Enabled"))
+	require.False(delta.Drift.DifferentAt("Spec.AllocatedStorage"))
+	require.Len(delta.Drift.Differences, 4)
+
+	require.True(delta.HasConflicts())
+	require.True(delta.Conflicts.DifferentAt("Spec.Labels.env"))
+	require.True(delta.Conflicts.DifferentAt("Spec.Config"))
+	require.False(delta.Conflicts.DifferentAt("Spec.Engine"))
+	require.False(delta.Conflicts.DifferentAt("Spec.AllocatedStorage"))
+	require.False(delta.Conflicts.DifferentAt("Spec.Labels.owner"))
+	require.Len(delta.Conflicts.Differences, 2)
+	for _, diff := range delta.Conflicts.Differences {
+		if diff.Path.String() == "Spec.Labels.env" {
+			require.Equal(strP("prod"), diff.A)
+			require.Equal(strP("test"), diff.B)
+		}
+	}
+}
+
+func TestThreeWay_NoConflicts(t *testing.T) {
+	require := require.New(t)
+
+	base := testResource{Spec: testSpec{Engine: strP("postgres")}}
+	desired := testResource{Spec: testSpec{Engine: strP("mysql")}}
+	latest := testResource{
+		Spec: testSpec{Engine: strP("postgres"), AllocatedStorage: int64P(20)},
+	}
+
+	delta, err := compare.ThreeWay(base, desired, latest)
+	require.NoError(err)
+	require.False(delta.HasConflicts())
+	require.Len(delta.UserChanges.Differences, 1)
+	require.Len(delta.Drift.Differences, 1)
+
+	_, err = compare.ThreeWay(base, &desired, latest)
+	require.Error(err)
+}
@@ -4,8 +4,8 @@ go 1.25.0
 
 require (
 	github.com/dlclark/regexp2 v1.10.0
-	github.com/iancoleman/strcase v0.2.0
 	github.com/stretchr/testify v1.8.4
+	gopkg.in/yaml.v3 v3.0.1
 	k8s.io/apimachinery v0.29.0
 )
 
@@ -22,7 +22,6 @@ require (
 	golang.org/x/text v0.37.0 // indirect
 	gopkg.in/inf.v0 v0.9.1 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 	k8s.io/klog/v2 v2.110.1 // indirect
 	k8s.io/utils v0.0.0-20230726121419-3b25d923346b // indirect
 	sigs.k8s.io/json v0.0.0-20221116044647-bc3834ca7abd // indirect
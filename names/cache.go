@@ -0,0 +1,175 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package names
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// DefaultCacheSize is the maximum number of entries held by the cache
+	// backing Cached.
+	DefaultCacheSize = 8192
+)
+
+var (
+	// rulesGeneration is incremented every time the initialism rules consulted
+	// by New are changed. Caches compare the generation they were filled
+	// under against this value and drop their entries when it has moved on.
+	rulesGeneration atomic.Uint64
+
+	defaultCache = NewCache(DefaultCacheSize)
+)
+
+// CacheStats contains hit and miss counters for a Cache.
+type CacheStats struct {
+	// Hits is the number of lookups that were answered from the cache
+	Hits uint64
+	// Misses is the number of lookups that required a call to New
+	Misses uint64
+	// Evictions is the number of entries removed to stay within the cache's
+	// size bound
+	Evictions uint64
+	// Invalidations is the number of times the cache was emptied because the
+	// initialism rules changed
+	Invalidations uint64
+	// Size is the number of entries currently held in the cache
+	Size int
+}
+
+// cacheEntry is the value stored in each element of Cache.lru
+type cacheEntry struct {
+	original string
+	names    Names
+}
+
+// Cache is a bounded, goroutine-safe memoizing wrapper around New. Once the
+// cache holds its maximum number of entries, the least recently used entry is
+// evicted to make room for a new one.
+//
+// Entries are discarded automatically when any initialism rules change (see
+// RegisterServiceInitialisms), so a Cache never returns a Names that New (or
+// Service.New, for a cache returned by Service.NewCache) would not.
+type Cache struct {
+	mu         sync.Mutex
+	service    Service
+	maxEntries int
+	generation uint64
+	lru        *list.List
+	entries    map[string]*list.Element
+	stats      CacheStats
+}
+
+// New returns the Names for the supplied original name, generating them only
+// if the result is not already cached.
+func (c *Cache) New(original string) Names {
+	gen := rulesGeneration.Load()
+	c.mu.Lock()
+	c.invalidateIfStale(gen)
+	if el, ok := c.entries[original]; ok {
+		c.lru.MoveToFront(el)
+		c.stats.Hits++
+		n := el.Value.(*cacheEntry).names
+		c.mu.Unlock()
+		return n
+	}
+	c.stats.Misses++
+	c.mu.Unlock()
+
+	// Names are a pure function of the input and the rules, so we avoid
+	// holding the lock while doing the (comparatively expensive) regexp
+	// matching.
+	n := c.service.New(original)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if gen != rulesGeneration.Load() {
+		// The rules changed while we were computing the names. The result is
+		// still correct for the caller but must not be cached.
+		return n
+	}
+	c.invalidateIfStale(gen)
+	if el, ok := c.entries[original]; ok {
+		// Another goroutine filled the entry while we were computing it.
+		c.lru.MoveToFront(el)
+		return n
+	}
+	c.entries[original] = c.lru.PushFront(&cacheEntry{original, n})
+	if c.maxEntries > 0 && c.lru.Len() > c.maxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).original)
+		c.stats.Evictions++
+	}
+	return n
+}
+
+// Stats returns a snapshot of the cache's hit and miss counters.
+func (c *Cache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Size = c.lru.Len()
+	return stats
+}
+
+// Purge removes all entries from the cache. Counters are left untouched.
+func (c *Cache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.purge()
+}
+
+// invalidateIfStale empties the cache if it was filled under a different rules
+// generation than the supplied one. The caller must hold the lock.
+func (c *Cache) invalidateIfStale(gen uint64) {
+	if c.generation == gen {
+		return
+	}
+	if c.lru.Len() > 0 {
+		c.stats.Invalidations++
+	}
+	c.purge()
+	c.generation = gen
+}
+
+// purge removes all entries from the cache. The caller must hold the lock.
+func (c *Cache) purge() {
+	c.lru.Init()
+	c.entries = map[string]*list.Element{}
+}
+
+// NewCache returns a new Cache holding at most maxEntries entries. A
+// maxEntries value of zero or less means the cache is unbounded.
+func NewCache(maxEntries int) *Cache {
+	return &Cache{
+		maxEntries: maxEntries,
+		generation: rulesGeneration.Load(),
+		lru:        list.New(),
+		entries:    map[string]*list.Element{},
+	}
+}
+
+// Cached returns the same Names as New, memoized in a package-level Cache
+// holding up to DefaultCacheSize entries. It is safe for concurrent use.
+func Cached(original string) Names {
+	return defaultCache.New(original)
+}
+
+// CachedStats returns the hit and miss counters of the cache backing Cached.
+func CachedStats() CacheStats {
+	return defaultCache.Stats()
+}
@@ -0,0 +1,116 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package names_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/pkg/names"
+)
+
+func TestCache(t *testing.T) {
+	require := require.New(t)
+
+	c := names.NewCache(2)
+	require.Equal(names.New("DbInstanceId"), c.New("DbInstanceId"))
+	require.Equal(names.New("DbInstanceId"), c.New("DbInstanceId"))
+	require.Equal(names.New("RoleArn"), c.New("RoleArn"))
+
+	stats := c.Stats()
+	require.Equal(uint64(1), stats.Hits)
+	require.Equal(uint64(2), stats.Misses)
+	require.Equal(uint64(0), stats.Evictions)
+	require.Equal(2, stats.Size)
+
+	// DbInstanceId was used less recently than RoleArn and should be evicted
+	c.New("VpcId")
+	stats = c.Stats()
+	require.Equal(uint64(1), stats.Evictions)
+	require.Equal(2, stats.Size)
+
+	c.New("RoleArn")
+	require.Equal(uint64(2), c.Stats().Hits)
+	c.New("DbInstanceId")
+	require.Equal(uint64(4), c.Stats().Misses)
+
+	c.Purge()
+	stats = c.Stats()
+	require.Equal(0, stats.Size)
+	require.Equal(uint64(4), stats.Misses)
+}
+
+func TestCache_Unbounded(t *testing.T) {
+	require := require.New(t)
+
+	c := names.NewCache(0)
+	for x := 0; x < 100; x++ {
+		c.New(fmt.Sprintf("Field%dId", x))
+	}
+	stats := c.Stats()
+	require.Equal(100, stats.Size)
+	require.Equal(uint64(0), stats.Evictions)
+}
+
+func TestCache_Concurrent(t *testing.T) {
+	require := require.New(t)
+
+	originals := []string{"DbInstanceId", "RoleArn", "VpcId", "HttpsPort", "Amis"}
+	c := names.NewCache(3)
+
+	var wg sync.WaitGroup
+	for x := 0; x < 8; x++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for y := 0; y < 200; y++ {
+				original := originals[y%len(originals)]
+				if c.New(original) != names.New(original) {
+					t.Errorf("cached names for %s differ from uncached", original)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats := c.Stats()
+	require.Equal(uint64(8*200), stats.Hits+stats.Misses)
+	require.LessOrEqual(stats.Size, 3)
+}
+
+func TestCached(t *testing.T) {
+	require := require.New(t)
+
+	before := names.CachedStats()
+	require.Equal(names.New("KmsKeyId"), names.Cached("KmsKeyId"))
+	require.Equal(names.New("KmsKeyId"), names.Cached("KmsKeyId"))
+	after := names.CachedStats()
+	require.Equal(before.Hits+before.Misses+2, after.Hits+after.Misses)
+	require.GreaterOrEqual(after.Hits, before.Hits+1)
+}
+
+func BenchmarkNew(b *testing.B) {
+	for x := 0; x < b.N; x++ {
+		names.New("DbInstanceIdentifier")
+	}
+}
+
+func BenchmarkCached(b *testing.B) {
+	for x := 0; x < b.N; x++ {
+		names.Cached("DbInstanceIdentifier")
+	}
+}
@@ -18,13 +18,12 @@ import (
 	"strings"
 
 	re2 "github.com/dlclark/regexp2" // for negative lookahead support
-	"github.com/iancoleman/strcase"
 
 	"github.com/aws-controllers-k8s/pkg/strutil"
 )
 
 var (
-	nonAlphaNumRegexp *regexp.Regexp = regexp.MustCompile("[^a-zA-Z0-9]+")
+	nonAlphaNumRegexp *regexp.Regexp = regexp.MustCompile(`[^\p{L}\p{N}]+`)
 )
 
 type initialismTranslator struct {
@@ -45,10 +44,6 @@ var (
 	// NOTE(jaypipes): these are ordered. Some things need to be processed
 	// before others. For example, we need to process "Dbi" before "Db"
 	initialisms = []initialismTranslator{
-		// Special... even though IDS is a valid initialism, in AWS APIs, the
-		// camel-cased "Ids" refers to a set of Identifiers, so the correct
-		// uppercase representation is "IDs"
-		{"Ids", "IDs", "ids", re2.MustCompile("(?![U|u])Ids", re2.None)},
 		// Need to prevent "Identifier" from becoming "IDentifier", and "Idle"
 		// from becoming "IDle" and "IdempotencyToken" from becoming
 		// "IDempotencyToken"
@@ -85,7 +80,6 @@ var (
 		{"IPSet", "IPSet", "ip_set", nil},
 		// Model fields containing AMI will always capitalize the 'A' hence we don't
 		// have to look for words starting with a lowercase 'A'
-		{"Amis", "AMIs", "amis", re2.MustCompile("Amis", re2.None)},
 		{"Ami", "AMI", "ami", re2.MustCompile("Ami", re2.None)},
 		// Easy find-and-replacements...
 		{"Acl", "ACL", "acl", nil},
@@ -192,7 +186,6 @@ var (
 		{"Uri", "URI", "uri", re2.MustCompile("(?!sec)uri(?!ty)|(Uri)|(URI)", re2.None)},
 		{"Url", "URL", "url", nil},
 		{"Uuid", "UUID", "uuid", nil},
-		{"Uids", "UIDs", "uids", re2.MustCompile("Uids", re2.None)},
 		{"Uid", "UID", "uid", re2.MustCompile("Uid", re2.None)},
 		// Need to prevent "Uid" or "Uuid" from becoming "UId" or "UUId"
 		{"Ui", "UI", "ui", re2.MustCompile("U(I|i)(?!D|d)", re2.None)},
@@ -208,6 +201,9 @@ var (
 	}
 )
 
+// globalRules contains the initialism rules applied by New
+var globalRules = newInitialismRules(initialisms)
+
 var goKeywords = []string{
 	"break",
 	"case",
@@ -246,37 +242,80 @@ type Names struct {
 	SnakeStripped string
 }
 
-// New returns a Names containing variations of a supplied name
+// New returns a Names containing variations of a supplied name.
+//
+// Word boundaries are determined the same way for the Camel, CamelLower,
+// Snake and SnakeStripped variations (Lower is always the original name,
+// lowercased verbatim):
+//
+//   - Any character that is neither a letter nor a digit (e.g. ".", ":", "-",
+//     "/", "_", "*" or whitespace) separates words and is dropped, e.g.
+//     "aws.region" and "aws:region" both become "AWSRegion" and "aws_region".
+//   - A run of digits belongs to the word it follows, e.g. "S3Key",
+//     "Ec2InstanceId" and "SaslScram512Auth" become "s3_key",
+//     "ec2_instance_id" and "sasl_scram512_auth" when snake-cased. This holds
+//     even when the digits are separated from the word by a separator, e.g.
+//     "Port_8080" becomes "port8080", but two runs of digits separated from
+//     each other stay apart, e.g. "x86_64" becomes "x86_64".
+//   - In the Camel and CamelLower variations, a letter directly following a
+//     run of digits is uppercased, e.g. "G4dn" becomes "G4Dn" and
+//     "NumberOf2xx" becomes "numberOf2Xx" when lower camel-cased. These
+//     variations become the Go field names and JSON tags of generated
+//     resources, so they are kept as they have always been generated.
+//   - In the Snake and SnakeStripped variations, lowercase letters directly
+//     following a run of digits continue the same word, e.g. "G4dn" becomes
+//     "g4dn", while an uppercase letter starts a new word. Earlier versions
+//     split digits into words of their own, e.g. "g_4_dn", "s_3_key" or
+//     "x_8664"; only the Snake variations changed.
+//   - A name beginning with digits keeps them at the start of its first word.
+//     Such names are not valid Go identifiers; see PackageName.
+//   - Letters outside of ASCII are kept and their case is mapped using
+//     Unicode rules, e.g. "Café" becomes "café" when lower camel-cased.
 func New(original string) Names {
+	return newNames(original, globalRules)
+}
+
+// newNames returns a Names containing variations of a supplied name, using
+// the supplied initialism rules
+func newNames(original string, rules *initialismRules) Names {
 	return Names{
 		Original:   original,
-		Camel:      goName(original, false, false),
-		CamelLower: goName(original, true, false),
+		Camel:      goName(original, false, false, rules),
+		CamelLower: goName(original, true, false, rules),
 		Lower:      strings.ToLower(original),
-		Snake:      goName(original, false, true),
+		Snake:      goName(original, false, true, rules),
 		SnakeStripped: nonAlphaNumRegexp.ReplaceAllString(
-			goName(original, false, true), "",
+			goName(original, false, true, rules), "",
 		),
 	}
 }
 
-func goName(original string, lowerFirst bool, snake bool) (result string) {
-	result = original
-	if !lowerFirst {
-		result = strcase.ToCamel(result)
+func goName(
+	original string,
+	lowerFirst bool,
+	snake bool,
+	rules *initialismRules,
+) (result string) {
+	result = rules.normalizePluralInitialisms(original)
+	if snake {
+		result = toSnakeWords(result)
+	} else if !lowerFirst {
+		result = toCamel(result)
 	}
-	result, err := normalizeInitialisms(result, lowerFirst, snake)
+	result, err := normalizeInitialisms(result, lowerFirst, snake, rules.translators)
 	if err != nil {
 		panic(err)
 	}
 	if lowerFirst {
-		result, err = normalizeInitialisms(strcase.ToLowerCamel(result), lowerFirst, snake)
+		result, err = normalizeInitialisms(
+			toLowerCamel(result), lowerFirst, snake, rules.translators,
+		)
 		if err != nil {
 			panic(err)
 		}
 	}
 	if snake {
-		result = strcase.ToSnake(result)
+		result = rules.joinPluralSuffixes(toSnake(result))
 	}
 	if strutil.InStrings(result, goKeywords) {
 		result = result + "_"
@@ -302,9 +341,14 @@ func goName(original string, lowerFirst bool, snake bool) (result string) {
 // RoleArn     | false      | RoleARN
 //
 // See: https://github.com/golang/go/wiki/CodeReviewComments#initialisms
-func normalizeInitialisms(original string, lowerFirst bool, snake bool) (result string, err error) {
+func normalizeInitialisms(
+	original string,
+	lowerFirst bool,
+	snake bool,
+	trxs []initialismTranslator,
+) (result string, err error) {
 	result = original
-	for _, initTrx := range initialisms {
+	for _, initTrx := range trxs {
 		if initTrx.re == nil {
 			if snake {
 				// If we need to snakecase, we need to look for the uppercase
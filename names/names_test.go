@@ -97,16 +97,13 @@ func TestNames(t *testing.T) {
 		{"IPAddressType", "IPAddressType", "ipAddressType", "ip_address_type", "ipaddresstype"},
 		{"IPSetType", "IPSetType", "ipSetType", "ip_set_type", "ipsettype"},
 		{"Ip", "IP", "ip", "ip", "ip"},
-		// The ipv_4/ipv_6 is a special case mainly caused by github.com/iancoleman/strcase
-		// which does not handle this case correctly. See https://github.com/iancoleman/strcase/issues/22
-		// This is OK for now as snake case is only used for package names and not for field names.
-		{"IPv4", "IPv4", "ipv4", "ipv_4", "ipv4"},
-		{"Ipv4", "IPv4", "ipv4", "ipv_4", "ipv4"},
-		{"IPv6", "IPv6", "ipv6", "ipv_6", "ipv6"},
-		{"Ipv6", "IPv6", "ipv6", "ipv_6", "ipv6"},
+		{"IPv4", "IPv4", "ipv4", "ipv4", "ipv4"},
+		{"Ipv4", "IPv4", "ipv4", "ipv4", "ipv4"},
+		{"IPv6", "IPv6", "ipv6", "ipv6", "ipv6"},
+		{"Ipv6", "IPv6", "ipv6", "ipv6", "ipv6"},
 		{"Ipam", "IPAM", "ipam", "ipam", "ipam"},
 		{"Ipc", "IPC", "ipc", "ipc", "ipc"},
-		{"Ja3", "JA3", "ja3", "ja_3", "ja3"},
+		{"Ja3", "JA3", "ja3", "ja3", "ja3"},
 		{"Frame", "Frame", "frame", "frame", "frame"},
 		{"KeyId", "KeyID", "keyID", "key_id", "keyid"},
 		{"KeyID", "KeyID", "keyID", "key_id", "keyid"},
@@ -137,7 +134,7 @@ func TestNames(t *testing.T) {
 		{"RamDiskId", "RAMDiskID", "ramDiskID", "ram_disk_id", "ramdiskid"},
 		{"RepositoryUriTest", "RepositoryURITest", "repositoryURITest", "repository_uri_test", "repositoryuritest"},
 		{"RequestedAmiVersion", "RequestedAMIVersion", "requestedAMIVersion", "requested_ami_version", "requestedamiversion"},
-		{"SaslScram512Auth", "SASLSCRAM512Auth", "saslSCRAM512Auth", "sasl_scram_512_auth", "saslscram512auth"},
+		{"SaslScram512Auth", "SASLSCRAM512Auth", "saslSCRAM512Auth", "sasl_scram512_auth", "saslscram512auth"},
 		{"Secret", "Secret", "secret", "secret", "secret"},
 		{"Secrets", "Secrets", "secrets", "secrets", "secrets"},
 		{"Sns", "SNS", "sns", "sns", "sns"},
@@ -171,6 +168,33 @@ func TestNames(t *testing.T) {
 		{"VpcEndpoint", "VPCEndpoint", "vpcEndpoint", "vpc_endpoint", "vpcendpoint"},
 		{"Xss", "XSS", "xss", "xss", "xss"},
 		{"MiBps", "MiBps", "miBps", "mi_bps", "mibps"},
+		// A trailing lowercase "s" following an initialism is a plural
+		{"Arns", "ARNs", "arns", "arns", "arns"},
+		{"ARNs", "ARNs", "arns", "arns", "arns"},
+		{"RoleArns", "RoleARNs", "roleARNs", "role_arns", "rolearns"},
+		{"RoleARNs", "RoleARNs", "roleARNs", "role_arns", "rolearns"},
+		{"ArnsList", "ARNsList", "arnsList", "arns_list", "arnslist"},
+		{"Vpcs", "VPCs", "vpcs", "vpcs", "vpcs"},
+		{"VPCs", "VPCs", "vpcs", "vpcs", "vpcs"},
+		{"Vpces", "VPCEs", "vpces", "vpces", "vpces"},
+		{"NetworkAcls", "NetworkACLs", "networkACLs", "network_acls", "networkacls"},
+		{"CallbackUrls", "CallbackURLs", "callbackURLs", "callback_urls", "callbackurls"},
+		{"Uris", "URIs", "uris", "uris", "uris"},
+		{"Ids", "IDs", "ids", "ids", "ids"},
+		{"IDs", "IDs", "ids", "ids", "ids"},
+		{"SubnetIDs", "SubnetIDs", "subnetIDs", "subnet_ids", "subnetids"},
+		{"SecurityGroupIds", "SecurityGroupIDs", "securityGroupIDs", "security_group_ids", "securitygroupids"},
+		{"Dbs", "DBs", "dbs", "dbs", "dbs"},
+		{"AllowedIps", "AllowedIPs", "allowedIPs", "allowed_ips", "allowedips"},
+		{"IPSets", "IPSets", "ipSets", "ip_sets", "ipsets"},
+		{"UIDs", "UIDs", "uids", "uids", "uids"},
+		{"TTLs", "TTLs", "ttls", "ttls", "ttls"},
+		{"MaxTTLs", "MaxTTLs", "maxTTLs", "max_ttls", "maxttls"},
+		// ... but not when followed by a lowercase letter
+		{"Arnsomething", "ARNsomething", "arnsomething", "arnsomething", "arnsomething"},
+		// ... nor when the initialism followed by "s" is itself an initialism
+		{"Https", "HTTPS", "https", "https", "https"},
+		{"Iops", "IOPS", "iops", "iops", "iops"},
 		{"LastDecreaseDateTime", "LastDecreaseDateTime", "lastDecreaseDateTime", "last_decrease_date_time", "lastdecreasedatetime"},
 		{"NumberOfDecreasesToday", "NumberOfDecreasesToday", "numberOfDecreasesToday", "number_of_decreases_today", "numberofdecreasestoday"},
 	}
@@ -0,0 +1,131 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package names
+
+import (
+	"go/token"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/aws-controllers-k8s/pkg/strutil"
+)
+
+// SDKImportAlias is the alias with which code generated for ACK controllers
+// conventionally imports the AWS SDK package of a service alongside the
+// controller's own package of the same name
+const SDKImportAlias = "svcsdk"
+
+const (
+	// packageNamePrefix is prepended to package names that would otherwise
+	// be invalid or would shadow a Go keyword, predeclared identifier or
+	// standard library package
+	packageNamePrefix = "svc"
+	// importAliasSuffix is appended to import aliases that collide with an
+	// already-taken identifier
+	importAliasSuffix = "sdk"
+)
+
+// goPredeclared is the list of Go's predeclared identifiers. While it is legal
+// to name a package after one of these, doing so shadows the identifier in
+// every file importing the package.
+var goPredeclared = []string{
+	"any", "append", "bool", "byte", "cap", "clear", "close", "comparable",
+	"complex", "complex128", "complex64", "copy", "delete", "error", "false",
+	"float32", "float64", "imag", "int", "int16", "int32", "int64", "int8",
+	"iota", "len", "make", "max", "min", "new", "nil", "panic", "print",
+	"println", "real", "recover", "rune", "string", "true", "uint", "uint16",
+	"uint32", "uint64", "uint8", "uintptr",
+}
+
+// goStdlibPackages is the list of (last path element) names of the packages
+// in the Go standard library, excluding internal and vendored packages. A
+// generated package of the same name would have to be aliased whenever it is
+// imported alongside the standard library package.
+var goStdlibPackages = []string{
+	"adler32", "aes", "ascii85", "asn1", "ast", "atomic", "base32", "base64",
+	"big", "binary", "bits", "bufio", "build", "buildinfo", "bytes", "bzip2",
+	"cgi", "cgo", "cipher", "cmp", "cmplx", "color", "comment", "constant",
+	"constraint", "context", "cookiejar", "coverage", "crc32", "crc64",
+	"crypto", "csv", "debug", "des", "doc", "draw", "driver", "dsa", "dwarf",
+	"ecdh", "ecdsa", "ed25519", "elf", "elliptic", "embed", "encoding",
+	"errors", "exec", "expvar", "fcgi", "filepath", "flag", "flate", "fmt",
+	"fnv", "format", "fs", "fstest", "gif", "gob", "gosym", "gzip", "hash",
+	"heap", "hex", "hkdf", "hmac", "html", "http", "httptest", "httptrace",
+	"httputil", "image", "importer", "io", "iotest", "ioutil", "iter", "jpeg",
+	"json", "jsonrpc", "list", "log", "lzw", "macho", "mail", "maphash",
+	"maps", "math", "md5", "metrics", "mime", "multipart", "net", "netip",
+	"os", "palette", "parse", "parser", "path", "pbkdf2", "pe", "pem", "pkix",
+	"plan9obj", "plugin", "png", "pprof", "printer", "quick",
+	"quotedprintable", "race", "rand", "rc4", "reflect", "regexp", "ring",
+	"rpc", "rsa", "runtime", "scanner", "sha1", "sha256", "sha3", "sha512",
+	"signal", "slices", "slog", "smtp", "sort", "sql", "strconv", "strings",
+	"structs", "subtle", "suffixarray", "sync", "syntax", "syscall", "syslog",
+	"tabwriter", "tar", "template", "testing", "textproto", "time", "tls",
+	"token", "trace", "types", "tzdata", "unicode", "unique", "unsafe", "url",
+	"user", "utf16", "utf8", "version", "weak", "x509", "xml", "zip", "zlib",
+}
+
+// PackageName returns a Go package name for the supplied original name, e.g.
+// "ElastiCache" -> "elasticache", "EC2" -> "ec2" and "SFN" -> "sfn".
+//
+// The returned name is always a valid, lowercase Go identifier containing no
+// underscores. Names that would begin with a digit, are empty, or collide
+// with a Go keyword, predeclared identifier or standard library package name
+// are prefixed with "svc", e.g. "Errors" -> "svcerrors".
+func PackageName(original string) string {
+	// Numeric characters other than decimal digits, e.g. "²", are not valid
+	// in Go identifiers
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, strings.ToLower(New(original).SnakeStripped))
+	// token.IsIdentifier is false for empty names, names beginning with any
+	// Unicode decimal digit, e.g. "٣", and Go keywords
+	if !token.IsIdentifier(name) ||
+		strutil.InStrings(name, goPredeclared) ||
+		strutil.InStrings(name, goStdlibPackages) {
+		name = packageNamePrefix + name
+	}
+	return name
+}
+
+// ImportAlias returns an identifier suitable for aliasing an import of the
+// package for the supplied original name that does not collide with any of
+// the supplied taken identifiers.
+//
+// The package name returned by PackageName is used if it is not taken.
+// Otherwise the conventional SDKImportAlias is used, so that
+// ImportAlias("ElastiCache", []string{"elasticache"}) returns "svcsdk", as
+// when generated code imports the AWS SDK package of the service it manages
+// alongside its own package. If that is also taken, e.g. because the SDK
+// packages of several services are imported together, "sdk" is appended to
+// the package name instead, followed by an increasing number until the alias
+// is unique, e.g. "elasticachesdk" and then "elasticachesdk2".
+func ImportAlias(original string, taken []string) string {
+	base := PackageName(original)
+	if !strutil.InStrings(base, taken) {
+		return base
+	}
+	if !strutil.InStrings(SDKImportAlias, taken) {
+		return SDKImportAlias
+	}
+	alias := base + importAliasSuffix
+	for x := 2; strutil.InStrings(alias, taken); x++ {
+		alias = base + importAliasSuffix + strconv.Itoa(x)
+	}
+	return alias
+}
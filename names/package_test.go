@@ -0,0 +1,92 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package names_test
+
+import (
+	"fmt"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws-controllers-k8s/pkg/names"
+)
+
+func TestPackageName(t *testing.T) {
+	assert := assert.New(t)
+
+	testCases := []struct {
+		original string
+		expect   string
+	}{
+		{"ElastiCache", "elasticache"},
+		{"Elasticache", "elasticache"},
+		{"EC2", "ec2"},
+		{"SFN", "sfn"},
+		{"sfn", "sfn"},
+		{"S3", "s3"},
+		{"Route53", "route53"},
+		{"ApiGatewayV2", "apigatewayv2"},
+		{"DynamoDB", "dynamodb"},
+		{"MemoryDB", "memorydb"},
+		{"ACM-PCA", "acmpca"},
+		{"cloudwatch-logs", "cloudwatchlogs"},
+		// Go keywords
+		{"Package", "svcpackage"},
+		{"Select", "svcselect"},
+		// Go predeclared identifiers
+		{"String", "svcstring"},
+		// Go standard library packages
+		{"Errors", "svcerrors"},
+		{"Time", "svctime"},
+		// Not valid Go identifiers on their own
+		{"3d", "svc3d"},
+		{"٣d", "svc٣d"},
+		{"S3²", "s3"},
+		{"", "svc"},
+	}
+	for _, tc := range testCases {
+		got := names.PackageName(tc.original)
+		msg := fmt.Sprintf("for original %s expected package name of %s but got %s", tc.original, tc.expect, got)
+		assert.Equal(tc.expect, got, msg)
+		assert.True(token.IsIdentifier(got), msg)
+		assert.False(token.IsKeyword(got), msg)
+	}
+}
+
+func TestImportAlias(t *testing.T) {
+	assert := assert.New(t)
+
+	testCases := []struct {
+		original string
+		taken    []string
+		expect   string
+	}{
+		{"ElastiCache", nil, "elasticache"},
+		{"ElastiCache", []string{"ec2"}, "elasticache"},
+		{"ElastiCache", []string{"elasticache"}, "svcsdk"},
+		{"ElastiCache", []string{"elasticache", "svcsdk"}, "elasticachesdk"},
+		{"ElastiCache", []string{"elasticache", "svcsdk", "elasticachesdk"}, "elasticachesdk2"},
+		{"ElastiCache", []string{"elasticache", "svcsdk", "elasticachesdk", "elasticachesdk2"}, "elasticachesdk3"},
+		{"Errors", []string{"svcerrors"}, "svcsdk"},
+		{"Errors", []string{"svcerrors", "svcsdk"}, "svcerrorssdk"},
+	}
+	for _, tc := range testCases {
+		got := names.ImportAlias(tc.original, tc.taken)
+		msg := fmt.Sprintf("for original %s and taken %v expected import alias of %s but got %s", tc.original, tc.taken, tc.expect, got)
+		assert.Equal(tc.expect, got, msg)
+		assert.True(token.IsIdentifier(got), msg)
+		assert.NotContains(tc.taken, got, msg)
+	}
+}
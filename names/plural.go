@@ -0,0 +1,182 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package names
+
+import (
+	"strings"
+)
+
+// initialismRules is an ordered list of initialism translators along with
+// the information needed to handle plural forms of those initialisms.
+//
+// A trailing lowercase "s" following a known initialism is treated as a
+// plural, e.g. "Arns" becomes "ARNs", "arns" or "arns" (snake) and "VPCs"
+// becomes "VPCs", "vpcs" or "vpcs" (snake). Initialisms are never pluralized
+// when they already end in "s" (e.g. "Iops", "Dns") or when appending an "s"
+// forms another initialism (e.g. "Http" and "Https").
+type initialismRules struct {
+	// translators is the ordered list of initialism translators
+	translators []initialismTranslator
+	// pluralUppers contains the pluralizable translators whose uppercase form
+	// is entirely uppercase letters and digits and differs from the
+	// CamelCased form, e.g. "ARN" for "Arn"
+	pluralUppers []initialismTranslator
+	// pluralLowers contains the lowercase forms of pluralizable initialisms
+	pluralLowers map[string]bool
+}
+
+// newInitialismRules returns an initialismRules for the supplied ordered list
+// of initialism translators
+func newInitialismRules(trxs []initialismTranslator) *initialismRules {
+	camels := make(map[string]bool, len(trxs))
+	for _, initTrx := range trxs {
+		camels[strings.ToLower(initTrx.camel)] = true
+	}
+	rules := &initialismRules{
+		translators:  trxs,
+		pluralLowers: map[string]bool{},
+	}
+	for _, initTrx := range trxs {
+		camel := strings.ToLower(initTrx.camel)
+		if strings.HasSuffix(camel, "s") || camels[camel+"s"] {
+			continue
+		}
+		// The CamelCased plural of an initialism such as "TTL" would be
+		// matched as another initialism, "Ttls" as "TLS", so its uppercase
+		// plural is left as is and the "s" that snake-casing splits from it
+		// is rejoined instead
+		if isUpperAlphaNum(initTrx.upper) && initTrx.upper != initTrx.camel &&
+			!formsOtherInitialism(camel, trxs) {
+			rules.pluralUppers = append(rules.pluralUppers, initTrx)
+		}
+		if isSnakeLower(initTrx.lower) {
+			rules.pluralLowers[initTrx.lower] = true
+		}
+	}
+	return rules
+}
+
+// formsOtherInitialism returns true if appending an "s" to the supplied
+// lowercased CamelCased initialism forms an occurrence of another initialism
+// matched without a regular expression, e.g. "ttls" contains "tls", in which
+// case the translators would split the plural differently
+func formsOtherInitialism(camel string, trxs []initialismTranslator) bool {
+	for _, initTrx := range trxs {
+		other := strings.ToLower(initTrx.camel)
+		if initTrx.re == nil && other != camel &&
+			strings.Contains(camel+"s", other) && !strings.Contains(camel, other) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizePluralInitialisms replaces the uppercase plural form of each
+// pluralizable initialism in the supplied subject with its CamelCased plural
+// form, e.g. "RoleARNs" becomes "RoleArns" and "IDs" becomes "Ids", so that
+// the initialism translators and word splitting handle the plural consistently
+// regardless of how it was capitalized in the original name.
+//
+// Only occurrences that start a word (i.e. are not preceded by another
+// uppercase letter) and are not followed by a lowercase letter are replaced,
+// so "UIDs" and "ARNsomething" are left alone.
+func (r *initialismRules) normalizePluralInitialisms(subject string) string {
+	for _, initTrx := range r.pluralUppers {
+		plural := initTrx.upper + "s"
+		start := 0
+		for {
+			pos := strings.Index(subject[start:], plural)
+			if pos == -1 {
+				break
+			}
+			pos += start
+			end := pos + len(plural)
+			start = end
+			if pos > 0 && isUpper(subject[pos-1]) {
+				continue
+			}
+			if end < len(subject) && isLower(subject[end]) {
+				continue
+			}
+			subject = subject[:pos] + initTrx.camel + "s" + subject[end:]
+		}
+	}
+	return subject
+}
+
+// joinPluralSuffixes rejoins a plural "s" that snake-casing split from the
+// preceding initialism, e.g. "vpc_s" becomes "vpcs"
+func (r *initialismRules) joinPluralSuffixes(snake string) string {
+	if !strings.Contains(snake, "_s") {
+		return snake
+	}
+	parts := strings.Split(snake, "_")
+	joined := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part == "s" && r.endsWithPluralizable(joined) {
+			joined[len(joined)-1] += part
+			continue
+		}
+		joined = append(joined, part)
+	}
+	return strings.Join(joined, "_")
+}
+
+// endsWithPluralizable returns true if the supplied snake-cased parts end with
+// the lowercase form of a pluralizable initialism. Lowercase forms may
+// themselves contain an underscore, e.g. "ip_set".
+func (r *initialismRules) endsWithPluralizable(parts []string) bool {
+	n := len(parts)
+	if n == 0 {
+		return false
+	}
+	if r.pluralLowers[parts[n-1]] {
+		return true
+	}
+	return n > 1 && r.pluralLowers[parts[n-2]+"_"+parts[n-1]]
+}
+
+func isUpper(c byte) bool {
+	return c >= 'A' && c <= 'Z'
+}
+
+func isLower(c byte) bool {
+	return c >= 'a' && c <= 'z'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isUpperAlphaNum returns true if the supplied string is non-empty and
+// contains only uppercase ASCII letters and digits
+func isUpperAlphaNum(subject string) bool {
+	for x := 0; x < len(subject); x++ {
+		if !isUpper(subject[x]) && !isDigit(subject[x]) {
+			return false
+		}
+	}
+	return subject != ""
+}
+
+// isSnakeLower returns true if the supplied string is non-empty and contains
+// only lowercase ASCII letters, digits and underscores
+func isSnakeLower(subject string) bool {
+	for x := 0; x < len(subject); x++ {
+		if !isLower(subject[x]) && !isDigit(subject[x]) && subject[x] != '_' {
+			return false
+		}
+	}
+	return subject != ""
+}
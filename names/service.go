@@ -0,0 +1,205 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package names
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	re2 "github.com/dlclark/regexp2"
+
+	"github.com/aws-controllers-k8s/pkg/strutil"
+)
+
+// Initialism describes how an initialism should be rendered in the variations
+// of a name returned by New.
+type Initialism struct {
+	// Camel is the CamelCased form of the initialism, e.g. "Tls"
+	Camel string
+	// Upper is the uppercase representation of the initialism, e.g. "TLS"
+	Upper string
+	// Lower is the lowercase representation of the initialism, e.g. "tls"
+	Lower string
+	// Pattern is an optional regular expression (in regexp2 syntax, which
+	// supports negative lookahead) matching the initialism within a subject
+	// string. If empty, Camel and Upper are matched literally.
+	Pattern string
+}
+
+// serviceRules contains the initialism additions and suppressions registered
+// for a single service
+type serviceRules struct {
+	// additions are processed before any of the global initialisms
+	additions []initialismTranslator
+	// suppressed contains the Camel forms of global initialisms that should
+	// not be applied for the service
+	suppressed []string
+	// rules contains the computed, ordered list of initialism translators
+	// for the service
+	rules *initialismRules
+}
+
+// compute rebuilds the list of initialism translators for the service from
+// its additions, its suppressions and the global initialisms
+func (r *serviceRules) compute() {
+	trxs := make([]initialismTranslator, 0, len(r.additions)+len(initialisms))
+	trxs = append(trxs, r.additions...)
+	for _, initTrx := range initialisms {
+		if !strutil.InStrings(initTrx.camel, r.suppressed) {
+			trxs = append(trxs, initTrx)
+		}
+	}
+	r.rules = newInitialismRules(trxs)
+}
+
+var (
+	serviceRulesLock sync.RWMutex
+	// serviceRulesByName is keyed by the lowercased service identifier
+	serviceRulesByName = map[string]*serviceRules{}
+)
+
+// Service applies the global initialisms along with any service-specific
+// additions and suppressions registered using RegisterServiceInitialisms and
+// SuppressServiceInitialisms.
+type Service struct {
+	name string
+}
+
+// ForService returns a Service for the supplied service identifier, e.g.
+// "rds". Service identifiers are case-insensitive.
+func ForService(service string) Service {
+	return Service{strings.ToLower(service)}
+}
+
+// Name returns the identifier of the service
+func (s Service) Name() string {
+	return s.name
+}
+
+// New returns a Names containing variations of a supplied name, taking into
+// account the service's initialism additions and suppressions.
+func (s Service) New(original string) Names {
+	return newNames(original, s.rules())
+}
+
+// NewCache returns a new Cache of the service's Names holding at most
+// maxEntries entries. A maxEntries value of zero or less means the cache is
+// unbounded.
+func (s Service) NewCache(maxEntries int) *Cache {
+	c := NewCache(maxEntries)
+	c.service = s
+	return c
+}
+
+// rules returns the initialism rules to apply for the service
+func (s Service) rules() *initialismRules {
+	if s.name == "" {
+		return globalRules
+	}
+	serviceRulesLock.RLock()
+	defer serviceRulesLock.RUnlock()
+	rules, ok := serviceRulesByName[s.name]
+	if !ok {
+		return globalRules
+	}
+	return rules.rules
+}
+
+// RegisterServiceInitialisms adds initialisms that are applied, in addition to
+// the global initialisms, when names are generated for the supplied service.
+// Service-specific initialisms are processed in the order supplied and before
+// any of the global initialisms, so they can be used to claim a series of
+// characters that a global initialism would otherwise match.
+//
+// For example:
+//
+//	names.RegisterServiceInitialisms("rds", names.Initialism{
+//	    Camel: "Rds", Upper: "RDS", Lower: "rds",
+//	})
+//
+// Any Cache, including the one backing Cached, is invalidated.
+func RegisterServiceInitialisms(service string, inits ...Initialism) error {
+	if service == "" {
+		return fmt.Errorf("service identifier must not be empty")
+	}
+	trxs := make([]initialismTranslator, 0, len(inits))
+	for _, init := range inits {
+		if init.Camel == "" || init.Upper == "" || init.Lower == "" {
+			return fmt.Errorf(
+				"initialism %q for service %q must have Camel, Upper and Lower forms",
+				init.Camel, service,
+			)
+		}
+		trx := initialismTranslator{init.Camel, init.Upper, init.Lower, nil}
+		if init.Pattern != "" {
+			re, err := re2.Compile(init.Pattern, re2.None)
+			if err != nil {
+				return fmt.Errorf(
+					"invalid pattern for initialism %q for service %q: %v",
+					init.Camel, service, err,
+				)
+			}
+			trx.re = re
+		}
+		trxs = append(trxs, trx)
+	}
+	updateServiceRules(service, func(rules *serviceRules) {
+		rules.additions = append(rules.additions, trxs...)
+	})
+	return nil
+}
+
+// SuppressServiceInitialisms prevents the global initialisms with the supplied
+// CamelCased forms from being applied when names are generated for the
+// supplied service. For example, suppressing "Ami" for a service renders
+// "AmiType" as "AmiType" instead of "AMIType".
+//
+// Any Cache, including the one backing Cached, is invalidated.
+func SuppressServiceInitialisms(service string, camels ...string) error {
+	if service == "" {
+		return fmt.Errorf("service identifier must not be empty")
+	}
+	updateServiceRules(service, func(rules *serviceRules) {
+		rules.suppressed = append(rules.suppressed, camels...)
+	})
+	return nil
+}
+
+// ResetServiceInitialisms removes all initialism additions and suppressions
+// registered for the supplied service.
+//
+// Any Cache, including the one backing Cached, is invalidated.
+func ResetServiceInitialisms(service string) {
+	serviceRulesLock.Lock()
+	defer serviceRulesLock.Unlock()
+	delete(serviceRulesByName, strings.ToLower(service))
+	rulesGeneration.Add(1)
+}
+
+// updateServiceRules applies the supplied mutation to the service's rules,
+// recomputes its initialism translators and invalidates all caches
+func updateServiceRules(service string, mutate func(*serviceRules)) {
+	serviceRulesLock.Lock()
+	defer serviceRulesLock.Unlock()
+	key := strings.ToLower(service)
+	rules, ok := serviceRulesByName[key]
+	if !ok {
+		rules = &serviceRules{}
+		serviceRulesByName[key] = rules
+	}
+	mutate(rules)
+	rules.compute()
+	rulesGeneration.Add(1)
+}
@@ -0,0 +1,104 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package names_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/pkg/names"
+)
+
+func TestForService_Additions(t *testing.T) {
+	require := require.New(t)
+
+	defer names.ResetServiceInitialisms("testadditions")
+	require.Nil(names.RegisterServiceInitialisms(
+		"testadditions",
+		names.Initialism{
+			Camel: "Ack", Upper: "ACK", Lower: "ack",
+			Pattern: "Ack(?!nowledge)",
+		},
+	))
+
+	svc := names.ForService("TestAdditions")
+	n := svc.New("AckEnabled")
+	require.Equal("ACKEnabled", n.Camel)
+	require.Equal("ackEnabled", n.CamelLower)
+	require.Equal("ack_enabled", n.Snake)
+	n = svc.New("AcknowledgedAt")
+	require.Equal("AcknowledgedAt", n.Camel)
+	// Global initialisms still apply
+	n = svc.New("AckRoleArn")
+	require.Equal("ACKRoleARN", n.Camel)
+	require.Equal("ackRoleARN", n.CamelLower)
+
+	// Other services and the global table are unaffected
+	require.Equal("AckEnabled", names.New("AckEnabled").Camel)
+	require.Equal("AckEnabled", names.ForService("rds").New("AckEnabled").Camel)
+}
+
+func TestForService_Suppressions(t *testing.T) {
+	require := require.New(t)
+
+	defer names.ResetServiceInitialisms("testsuppressions")
+	require.Nil(names.SuppressServiceInitialisms("testsuppressions", "Ami", "Acm"))
+
+	svc := names.ForService("testsuppressions")
+	require.Equal("AmiType", svc.New("AmiType").Camel)
+	require.Equal("amiType", svc.New("AmiType").CamelLower)
+	require.Equal("AcmEndpointARN", svc.New("AcmEndpointArn").Camel)
+
+	require.Equal("AMIType", names.New("AmiType").Camel)
+	require.Equal("ACMEndpointARN", names.New("AcmEndpointArn").Camel)
+
+	names.ResetServiceInitialisms("testsuppressions")
+	require.Equal("AMIType", svc.New("AmiType").Camel)
+}
+
+func TestForService_Errors(t *testing.T) {
+	require := require.New(t)
+
+	require.NotNil(names.RegisterServiceInitialisms(
+		"", names.Initialism{Camel: "Ack", Upper: "ACK", Lower: "ack"},
+	))
+	require.NotNil(names.RegisterServiceInitialisms(
+		"testerrors", names.Initialism{Camel: "Ack", Upper: "ACK"},
+	))
+	require.NotNil(names.RegisterServiceInitialisms(
+		"testerrors", names.Initialism{
+			Camel: "Ack", Upper: "ACK", Lower: "ack", Pattern: "Ack(?!",
+		},
+	))
+	require.NotNil(names.SuppressServiceInitialisms("", "Ami"))
+}
+
+func TestForService_CacheInvalidation(t *testing.T) {
+	require := require.New(t)
+
+	defer names.ResetServiceInitialisms("testcache")
+	svc := names.ForService("testcache")
+	c := svc.NewCache(10)
+	require.Equal("AMIType", c.New("AmiType").Camel)
+	require.Equal("AMIType", c.New("AmiType").Camel)
+	require.Equal(uint64(1), c.Stats().Hits)
+
+	require.Nil(names.SuppressServiceInitialisms("testcache", "Ami"))
+	require.Equal("AmiType", c.New("AmiType").Camel)
+	stats := c.Stats()
+	require.Equal(uint64(1), stats.Invalidations)
+	require.Equal(uint64(2), stats.Misses)
+	require.Equal(1, stats.Size)
+}
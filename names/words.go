@@ -0,0 +1,133 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package names
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// The functions in this file split names into words and re-join them in camel
+// or snake case, implementing the word boundary policy documented on New.
+
+var (
+	// snakeDigitRegexp matches a delimiter separating a run of digits from
+	// the letter preceding it. Delimiters between two runs of digits are
+	// kept, e.g. "x86_64".
+	snakeDigitRegexp = regexp.MustCompile(`(\p{L})_+(\p{N})`)
+)
+
+// toCamel returns the supplied subject with the first letter of each word
+// uppercased and all word separators removed. A letter following a digit is
+// also uppercased, e.g. "G4dn" becomes "G4Dn", which keeps the Go field names
+// and JSON tags of already generated resources stable.
+func toCamel(subject string) string {
+	return toCamelInitCase(subject, true, false)
+}
+
+// toLowerCamel returns the supplied subject with the first letter of each
+// word, except the very first letter, uppercased and all word separators
+// removed. As with toCamel, a letter following a digit is uppercased.
+func toLowerCamel(subject string) string {
+	return toCamelInitCase(subject, false, false)
+}
+
+// toSnakeWords returns the supplied subject camel-cased for toSnake. Unlike
+// toCamel, letters following a digit keep their case, so that lowercase
+// letters continue the word, e.g. "G4dn", and a separator between two digits
+// is kept as "_", so that digit groups stay apart, e.g. "X86_64".
+func toSnakeWords(subject string) string {
+	return toCamelInitCase(subject, true, true)
+}
+
+func toCamelInitCase(subject string, upperFirst bool, snakeWords bool) string {
+	subject = strings.TrimSpace(subject)
+	if subject == "" {
+		return subject
+	}
+	if subject == "ID" {
+		// The only acronym whose whole-string form is special-cased
+		subject = "id"
+	}
+	var b strings.Builder
+	b.Grow(len(subject))
+	capNext := upperFirst
+	first := true
+	prevDigit := false
+	separated := false
+	for _, r := range subject {
+		switch {
+		case unicode.IsLetter(r):
+			if capNext {
+				r = unicode.ToUpper(r)
+			} else if first {
+				r = unicode.ToLower(r)
+			}
+			b.WriteRune(r)
+			capNext = false
+			first = false
+			prevDigit = false
+		case unicode.IsDigit(r):
+			if snakeWords && prevDigit && separated {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r)
+			capNext = !snakeWords
+			first = false
+			prevDigit = true
+		default:
+			capNext = !first || upperFirst
+			separated = true
+			continue
+		}
+		separated = false
+	}
+	return b.String()
+}
+
+// toSnake returns the supplied subject lowercased with words separated by a
+// single underscore
+func toSnake(subject string) string {
+	runes := []rune(strings.TrimSpace(subject))
+	var b strings.Builder
+	b.Grow(len(runes) + 2)
+	for i, r := range runes {
+		isUpper := unicode.IsUpper(r)
+		isLower := unicode.IsLower(r)
+		isDigit := unicode.IsDigit(r)
+		if !isUpper && !isLower && !isDigit {
+			b.WriteByte('_')
+			continue
+		}
+		if i+1 < len(runes) {
+			next := runes[i+1]
+			// An uppercase letter followed by a lowercase letter starts a new
+			// word if it follows another uppercase letter, e.g. the "D" in
+			// "JSONData"
+			if isUpper && unicode.IsLower(next) && i > 0 && unicode.IsUpper(runes[i-1]) {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+			// A lowercase letter or a digit followed by an uppercase letter
+			// ends the word
+			if (isLower || isDigit) && unicode.IsUpper(next) {
+				b.WriteByte('_')
+			}
+			continue
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return snakeDigitRegexp.ReplaceAllString(b.String(), "$1$2")
+}
@@ -0,0 +1,121 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package names_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws-controllers-k8s/pkg/names"
+)
+
+type wordsTestCase struct {
+	original            string
+	expectCamel         string
+	expectCamelLower    string
+	expectSnake         string
+	expectSnakeStripped string
+}
+
+func assertWords(t *testing.T, testCases []wordsTestCase) {
+	assert := assert.New(t)
+
+	for _, tc := range testCases {
+		n := names.New(tc.original)
+		msg := fmt.Sprintf("for original %s expected camel name of %s but got %s", tc.original, tc.expectCamel, n.Camel)
+		assert.Equal(tc.expectCamel, n.Camel, msg)
+		msg = fmt.Sprintf("for original %s expected lowercase camel name of %s but got %s", tc.original, tc.expectCamelLower, n.CamelLower)
+		assert.Equal(tc.expectCamelLower, n.CamelLower, msg)
+		msg = fmt.Sprintf("for original %s expected snake name of %s but got %s", tc.original, tc.expectSnake, n.Snake)
+		assert.Equal(tc.expectSnake, n.Snake, msg)
+		msg = fmt.Sprintf("for original %s expected snake stripped name of %s but got %s", tc.original, tc.expectSnakeStripped, n.SnakeStripped)
+		assert.Equal(tc.expectSnakeStripped, n.SnakeStripped, msg)
+	}
+}
+
+// TestNames_Digits uses member names containing digits found in AWS API
+// models
+func TestNames_Digits(t *testing.T) {
+	assertWords(t, []wordsTestCase{
+		// Lambda FunctionCode
+		{"S3Bucket", "S3Bucket", "s3Bucket", "s3_bucket", "s3bucket"},
+		{"S3Key", "S3Key", "s3Key", "s3_key", "s3key"},
+		{"S3ObjectVersion", "S3ObjectVersion", "s3ObjectVersion", "s3_object_version", "s3objectversion"},
+		{"CodeSha256", "CodeSHA256", "codeSHA256", "code_sha256", "codesha256"},
+		// SageMaker
+		{"S3Uri", "S3URI", "s3URI", "s3_uri", "s3uri"},
+		// Firehose
+		{"ExtendedS3DestinationConfiguration", "ExtendedS3DestinationConfiguration", "extendedS3DestinationConfiguration", "extended_s3_destination_configuration", "extendeds3destinationconfiguration"},
+		// EMR
+		{"Ec2KeyName", "EC2KeyName", "ec2KeyName", "ec2_key_name", "ec2keyname"},
+		{"Ec2SubnetIds", "EC2SubnetIDs", "ec2SubnetIDs", "ec2_subnet_ids", "ec2subnetids"},
+		{"Ec2InstanceId", "EC2InstanceID", "ec2InstanceID", "ec2_instance_id", "ec2instanceid"},
+		// RDS
+		{"EC2SecurityGroupOwnerId", "EC2SecurityGroupOwnerID", "ec2SecurityGroupOwnerID", "ec2_security_group_owner_id", "ec2securitygroupownerid"},
+		// EC2
+		{"Ipv6CidrBlock", "IPv6CIDRBlock", "ipv6CIDRBlock", "ipv6_cidr_block", "ipv6cidrblock"},
+		{"AssignIpv6AddressOnCreation", "AssignIPv6AddressOnCreation", "assignIPv6AddressOnCreation", "assign_ipv6_address_on_creation", "assignipv6addressoncreation"},
+		{"Ipv4IpamPoolId", "IPv4IPAMPoolID", "ipv4IPAMPoolID", "ipv4_ipam_pool_id", "ipv4ipampoolid"},
+		{"G4dn", "G4Dn", "g4Dn", "g4dn", "g4dn"},
+		// EKS
+		{"ServiceIpv4Cidr", "ServiceIPv4CIDR", "serviceIPv4CIDR", "service_ipv4_cidr", "serviceipv4cidr"},
+		// CloudFront
+		{"IsIPV6Enabled", "IsIPV6Enabled", "isIPV6Enabled", "is_ipv6_enabled", "isipv6enabled"},
+		{"Http2Enabled", "HTTP2Enabled", "http2Enabled", "http2_enabled", "http2enabled"},
+		// S3
+		{"ChecksumSHA256", "ChecksumSHA256", "checksumSHA256", "checksum_sha256", "checksumsha256"},
+		{"ContentMD5", "ContentMD5", "contentMD5", "content_md5", "contentmd5"},
+		// SQS
+		{"MD5OfMessageBody", "MD5OfMessageBody", "md5OfMessageBody", "md5_of_message_body", "md5ofmessagebody"},
+		// WAFv2
+		{"Ja3Fingerprint", "JA3Fingerprint", "ja3Fingerprint", "ja3_fingerprint", "ja3fingerprint"},
+		// MSK
+		{"SaslScram512Auth", "SASLSCRAM512Auth", "saslSCRAM512Auth", "sasl_scram512_auth", "saslscram512auth"},
+		// ACM PCA
+		{"X509Certificate", "X509Certificate", "x509Certificate", "x509_certificate", "x509certificate"},
+		// API Gateway V2
+		{"ApiV2", "APIV2", "apiV2", "api_v2", "apiv2"},
+		{"TlsV13", "TLSV13", "tlsV13", "tls_v13", "tlsv13"},
+		// Letters following digits are uppercased in Camel and CamelLower, as
+		// they always have been, but continue the word in Snake
+		{"NumberOf2xx", "NumberOf2Xx", "numberOf2Xx", "number_of2xx", "numberof2xx"},
+		// CloudWatch metric names
+		{"Errors5xx", "Errors5Xx", "errors5Xx", "errors5xx", "errors5xx"},
+		{"HTTPCode_ELB_5XX_Count", "HTTPCodeELB5XXCount", "httpCodeELB5XXCount", "http_code_elb5_xx_count", "httpcodeelb5xxcount"},
+		// Digits starting a name stay at the start of the first word
+		{"2fa", "2Fa", "2Fa", "2fa", "2fa"},
+		// Digits separated from the preceding word still belong to it, but
+		// separated runs of digits stay apart in Snake
+		{"Port_8080", "Port8080", "port8080", "port8080", "port8080"},
+		{"x86_64", "X8664", "x8664", "x86_64", "x8664"},
+	})
+}
+
+func TestNames_NonIdentifierCharacters(t *testing.T) {
+	assertWords(t, []wordsTestCase{
+		{"aws.region", "AWSRegion", "awsRegion", "aws_region", "awsregion"},
+		{"aws:region", "AWSRegion", "awsRegion", "aws_region", "awsregion"},
+		{"s3:ObjectCreated:*", "S3ObjectCreated", "s3ObjectCreated", "s3_object_created", "s3objectcreated"},
+		{"my-name", "MyName", "myName", "my_name", "myname"},
+		{"/path/to", "PathTo", "pathTo", "path_to", "pathto"},
+		{"foo bar", "FooBar", "fooBar", "foo_bar", "foobar"},
+		{"  Spaced  ", "Spaced", "spaced", "spaced", "spaced"},
+		{"Foo__Bar", "FooBar", "fooBar", "foo_bar", "foobar"},
+		// Non-ASCII letters are kept
+		{"Café", "Café", "café", "café", "café"},
+		{"Ünicode", "Ünicode", "ünicode", "ünicode", "ünicode"},
+	})
+}
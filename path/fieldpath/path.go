@@ -121,6 +121,11 @@ func (p *Path) Size() int {
 	return len(p.parts)
 }
 
+// Parts returns a copy of the parts of the Path
+func (p *Path) Parts() []string {
+	return append([]string{}, p.parts...)
+}
+
 // HasPrefix returns true if the supplied string, delimited on ".", matches
 // p.parts up to the length of the supplied string.
 // e.g. if the Path p represents "A.B":
@@ -167,3 +172,9 @@ func (p *Path) HasPrefixFold(subject string) bool {
 func FromString(dotted string) *Path {
 	return &Path{strings.Split(dotted, ".")}
 }
+
+// FromParts returns a new Path struct pointer containing a copy of the
+// supplied parts. Unlike FromString, parts are not split on ".".
+func FromParts(parts ...string) *Path {
+	return &Path{append([]string{}, parts...)}
+}
@@ -70,3 +70,19 @@ func TestHasPrefix(t *testing.T) {
 	require.False(p.HasPrefix("author"))
 	require.True(p.HasPrefixFold("author"))
 }
+
+func TestFromParts(t *testing.T) {
+	require := require.New(t)
+
+	parts := []string{"Spec", "Tags", "a.b"}
+	p := fieldpath.FromParts(parts...)
+	require.Equal(3, p.Size())
+	require.Equal("a.b", p.Back())
+	require.Equal(parts, p.Parts())
+
+	// Neither the supplied nor the returned parts are shared with the Path
+	parts[0] = "Status"
+	require.Equal("Spec", p.Front())
+	p.Parts()[0] = "Status"
+	require.Equal("Spec", p.Front())
+}